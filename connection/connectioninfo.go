@@ -1,12 +1,12 @@
 package connection
 
 import (
-	"database/sql"
 	"reflect"
 	"strings"
 
 	"github.com/go-errors/errors"
 	"github.com/verticalgmbh/database-go/entities/models"
+	"github.com/verticalgmbh/database-go/interfaces"
 
 	"github.com/verticalgmbh/database-go/xpr"
 )
@@ -17,9 +17,13 @@ type IConnectionInfo interface {
 	// EvaluateParameter - literal used to specify parameters
 	//
 	// **Parameters**
-	//   - function: function to evaluate
-	//   - command: command to write evaluation result to
-	EvaluateParameter(parameter *xpr.ParameterNode, command *strings.Builder)
+	//   - function:   function to evaluate
+	//   - parameters: parameters already written to the command
+	//   - command:    command to write evaluation result to
+	//
+	// **Returns**
+	//   - error: error if parameter can not be represented in database
+	EvaluateParameter(parameter *xpr.ParameterNode, parameters *Parameters, command *strings.Builder) error
 
 	// MaskColumn masks a column for use in an sql statement
 	//
@@ -30,7 +34,8 @@ type IConnectionInfo interface {
 	//   - string: masked column name
 	MaskColumn(name string) string
 
-	// GetDatabaseType get type used in database
+	// GetDatabaseType get type used in database. Nullable types like pointers or sql.NullString
+	//                 are represented by the type of their values.
 	//
 	// **Parameters**
 	//   - type: application data type
@@ -46,15 +51,50 @@ type IConnectionInfo interface {
 	//   - command: command to write evaluation result to
 	EvaluateFunction(function *xpr.FunctionNode, command *strings.Builder, eval func(interface{}) error) error
 
+	// EvaluateOrder evaluates representation of an order criteria in database
+	//
+	// **Parameters**
+	//   - order:   order criteria to evaluate
+	//   - command: command to write evaluation result to
+	//   - eval:    function used to evaluate expressions
+	EvaluateOrder(order *xpr.OrderNode, command *strings.Builder, eval func(interface{}) error) error
+
+	// EvaluateJoin evaluates representation of a join operator in database
+	//
+	// **Parameters**
+	//   - jointype: type of join to evaluate
+	//   - command:  command to write evaluation result to
+	//
+	// **Returns**
+	//   - error: error if database does not support the join type
+	EvaluateJoin(jointype xpr.JoinType, command *strings.Builder) error
+
+	// EvaluateWith evaluates representation of the keyword introducing common table expressions
+	//
+	// **Parameters**
+	//   - recursive: determines whether any of the common table expressions is recursive
+	//   - command:   command to write evaluation result to
+	EvaluateWith(recursive bool, command *strings.Builder)
+
+	// EvaluateLimit evaluates representation of a row limit in database
+	//
+	// **Parameters**
+	//   - limit:   expression specifying maximum number of rows to return, nil if number of rows is not limited
+	//   - offset:  expression specifying number of rows to skip, nil if no rows are skipped
+	//   - ordered: determines whether the command already contains an ORDER BY clause
+	//   - command: command to write evaluation result to
+	//   - eval:    function used to evaluate expressions
+	EvaluateLimit(limit interface{}, offset interface{}, ordered bool, command *strings.Builder, eval func(interface{}) error) error
+
 	// ExistsTableOrView determines whether a table exists in database
 	//
 	// **Parameters**
-	//   - connection: connection to database
+	//   - connection: connection or transaction used to query the database
 	//   - name: name of table or view
 	//
 	// **Returns**
 	//   - bool: true if table or view exists, false otherwise
-	ExistsTableOrView(connection *sql.DB, name string) (bool, error)
+	ExistsTableOrView(connection interfaces.IExecutor, name string) (bool, error)
 
 	// CreateColumn creates sql text to use when creating a column
 	//
@@ -63,16 +103,41 @@ type IConnectionInfo interface {
 	//   - command: command builder string
 	CreateColumn(column *models.ColumnDescriptor, command *strings.Builder)
 
+	// AddColumn creates sql text to use when adding a column to an existing table
+	//
+	// **Parameters**
+	//   - table:   name of table to add column to
+	//   - column:  column to add
+	//   - command: command builder string
+	AddColumn(table string, column *models.ColumnDescriptor, command *strings.Builder)
+
+	// DropIndex creates sql text to use when removing an index
+	//
+	// **Parameters**
+	//   - table:    name of table containing index
+	//   - name:     name of index to remove
+	//   - ifexists: only remove index if it exists, databases not supporting this write nothing
+	//   - command:  command builder string
+	DropIndex(table string, name string, ifexists bool, command *strings.Builder)
+
+	// RenameTable creates sql text to use when renaming a table
+	//
+	// **Parameters**
+	//   - oldname: current name of table
+	//   - newname: name to rename table to
+	//   - command: command builder string
+	RenameTable(oldname string, newname string, command *strings.Builder)
+
 	// GetSchema get schema of a table or view in database
 	//
 	// **Parameters**
-	//   - connection: connection to database
+	//   - connection: connection or transaction used to query the database
 	//   - name: name of table or view
 	//
 	// **Returns**
 	//   - *Schema: schema information retrieved from database
 	//   - error: error information if any error occured
-	GetSchema(connection *sql.DB, name string) (models.Schema, error)
+	GetSchema(connection interfaces.IExecutor, name string) (models.Schema, error)
 
 	// GetSchemas get all schemas in database
 	//
@@ -82,17 +147,106 @@ type IConnectionInfo interface {
 	// **Returns**
 	//   - []Schema: schemas in database
 	//   - error   : errors if any occured
-	GetSchemas(connection *sql.DB) ([]models.Schema, error)
+	GetSchemas(connection interfaces.IExecutor) ([]models.Schema, error)
+
+	// EvaluateUpsert evaluates representation of an insert operation which updates existing rows on conflict
+	//
+	// **Parameters**
+	//   - upsert:  upsert operation to evaluate
+	//   - command: command to write evaluation result to
+	//
+	// **Returns**
+	//   - error: error if upsert can not be represented in database
+	EvaluateUpsert(upsert *Upsert, command *strings.Builder) error
+
+	// EvaluateReturning evaluates representation of fields returned by a data modification command.
+	//                   Called for every position at which databases support to specify returned fields.
+	//
+	// **Parameters**
+	//   - position: position in command which is currently written
+	//   - deleted:  determines whether command deletes rows, so fields of deleted rows are returned
+	//   - fields:   expressions specifying fields to return
+	//   - command:  command to write evaluation result to
+	//   - eval:     function used to evaluate expressions
+	//
+	// **Returns**
+	//   - error: error if database does not support to return fields
+	EvaluateReturning(position ReturningPosition, deleted bool, fields []interface{}, command *strings.Builder, eval func(interface{}) error) error
+
+	// EvaluateSavepoint evaluates representation of an operation on a savepoint of a transaction.
+	//                   Writes nothing if the operation is not necessary in database.
+	//
+	// **Parameters**
+	//   - operation: operation to evaluate
+	//   - name:      name of savepoint
+	//   - command:   command to write evaluation result to
+	//
+	// **Returns**
+	//   - error: error if database does not support the operation
+	EvaluateSavepoint(operation SavepointOperation, name string, command *strings.Builder) error
+
+	// IsRetryable determines whether an error is caused by a conflict with a concurrent transaction
+	//             like a lock timeout, a deadlock or a serialization failure. Transactions failing
+	//             with such an error can succeed if they are run again. Errors are identified by the code
+	//             provided by the driver error, which has to be part of the error chain.
+	//
+	// **Parameters**
+	//   - err: error to evaluate
+	//
+	// **Returns**
+	//   - bool: true if operation which caused the error can get retried, false otherwise
+	IsRetryable(err error) bool
+
+	// MaxBatchRows maximum number of rows which can be inserted using a single statement
+	//
+	// **Parameters**
+	//   - columns: number of columns filled per row
+	//
+	// **Returns**
+	//   - int: maximum number of rows per statement, 0 if a single row exceeds the number of supported parameters
+	MaxBatchRows(columns int) int
 
-	// Adds statement to command which returns identity of last inserted row
-	ReturnIdentity(command *strings.Builder) string
+	// ReturnIdentity adds statement to command which returns identity of last inserted row
+	//
+	// **Parameters**
+	//   - identity: column containing the identity of the row, nil if model has no identity column
+	//   - command:  statement to modify
+	//
+	// **Returns**
+	//   - bool:  true if the command returns the identity as result row, false if the identity is provided
+	//            by the execution result of the command as last insert id
+	//   - error: error if the identity can not get returned by the database
+	ReturnIdentity(identity *models.ColumnDescriptor, command *strings.Builder) (bool, error)
 }
 
 // EvaluateFunction function node evaluation which should work on all databases
 func EvaluateFunction(function *xpr.FunctionNode, command *strings.Builder, eval func(interface{}) error) (bool, error) {
 	switch function.Function() {
 	case xpr.FunctionCount:
-		command.WriteString("COUNT()")
+		switch len(function.Parameters()) {
+		case 0:
+			command.WriteString("COUNT(*)")
+		case 1:
+			command.WriteString("COUNT(")
+			err := eval(function.Parameters()[0])
+			if err != nil {
+				return false, err
+			}
+			command.WriteRune(')')
+		default:
+			return false, errors.Errorf("Function Count expects at most one parameter")
+		}
+	case xpr.FunctionCountDistinct:
+		if len(function.Parameters()) != 1 {
+			return false, errors.Errorf("Function CountDistinct expects exactly one parameter")
+		}
+
+		command.WriteString("COUNT(DISTINCT ")
+		err := eval(function.Parameters()[0])
+		if err != nil {
+			return false, err
+		}
+		command.WriteRune(')')
 	case xpr.FunctionAverage:
 		if len(function.Parameters()) != 1 {
 			return false, errors.Errorf("Function Average expects exactly one parameter")
@@ -148,3 +302,104 @@ func EvaluateFunction(function *xpr.FunctionNode, command *strings.Builder, eval
 
 	return true, nil
 }
+
+// EvaluateOrder order criteria evaluation which should work on all databases
+//
+// **Parameters**
+//   - order:          order criteria to evaluate
+//   - command:        command to write evaluation result to
+//   - eval:           function used to evaluate expressions
+//   - nullssupported: determines whether database supports NULLS FIRST/LAST, if not the ordering of nulls is emulated
+func EvaluateOrder(order *xpr.OrderNode, command *strings.Builder, eval func(interface{}) error, nullssupported bool) error {
+	if order.Nulls() != xpr.NullsDefault && !nullssupported {
+		command.WriteString("CASE WHEN ")
+		err := eval(order.Field())
+		if err != nil {
+			return err
+		}
+
+		if order.Nulls() == xpr.NullsFirst {
+			command.WriteString(" IS NULL THEN 0 ELSE 1 END,")
+		} else {
+			command.WriteString(" IS NULL THEN 1 ELSE 0 END,")
+		}
+	}
+
+	err := eval(order.Field())
+	if err != nil {
+		return err
+	}
+
+	if order.Descending() {
+		command.WriteString(" DESC")
+	} else {
+		command.WriteString(" ASC")
+	}
+
+	if nullssupported {
+		switch order.Nulls() {
+		case xpr.NullsFirst:
+			command.WriteString(" NULLS FIRST")
+		case xpr.NullsLast:
+			command.WriteString(" NULLS LAST")
+		}
+	}
+
+	return nil
+}
+
+// EvaluateJoin join operator evaluation which should work on all databases
+//
+// **Parameters**
+//   - jointype: type of join to evaluate
+//   - command:  command to write evaluation result to
+//
+// **Returns**
+//   - error: error if join type is unknown
+func EvaluateJoin(jointype xpr.JoinType, command *strings.Builder) error {
+	switch jointype {
+	case xpr.JoinInner:
+		command.WriteString("INNER JOIN")
+	case xpr.JoinLeft:
+		command.WriteString("LEFT OUTER JOIN")
+	case xpr.JoinRight:
+		command.WriteString("RIGHT OUTER JOIN")
+	case xpr.JoinFull:
+		command.WriteString("FULL OUTER JOIN")
+	case xpr.JoinCross:
+		command.WriteString("CROSS JOIN")
+	default:
+		return errors.Errorf("Invalid join type %v", jointype)
+	}
+
+	return nil
+}
+
+// EvaluateWith evaluation of common table expression keyword which should work on most databases
+//
+// **Parameters**
+//   - recursive: determines whether any of the common table expressions is recursive
+//   - command:   command to write evaluation result to
+func EvaluateWith(recursive bool, command *strings.Builder) {
+	command.WriteString("WITH ")
+	if recursive {
+		command.WriteString("RECURSIVE ")
+	}
+}
+
+// MaxBatchRows maximum number of rows which can be inserted using a single statement when
+//              the number of parameters per statement is limited
+//
+// **Parameters**
+//   - columns:       number of columns filled per row
+//   - maxparameters: maximum number of parameters supported in a statement
+//
+// **Returns**
+//   - int: maximum number of rows per statement, 0 if a single row exceeds the number of supported parameters
+func MaxBatchRows(columns int, maxparameters int) int {
+	if columns <= 0 {
+		return 1
+	}
+
+	return maxparameters / columns
+}
@@ -0,0 +1,616 @@
+package connection
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/verticalgmbh/database-go/entities/models"
+	"github.com/verticalgmbh/database-go/interfaces"
+	"github.com/verticalgmbh/database-go/xpr"
+)
+
+var mysqlintegertype = regexp.MustCompile("^(TINYINT|SMALLINT|MEDIUMINT|INT|BIGINT)\\(\\d+\\)")
+
+// MySQLInfo - mysql and mariadb specific information
+type MySQLInfo struct {
+}
+
+// NewMySQLInfo creates a new mysql info
+//
+// **Returns**
+//   - *MySQLInfo: created mysql connection info
+func NewMySQLInfo() *MySQLInfo {
+	return &MySQLInfo{}
+}
+
+// EvaluateParameter - literal used to specify parameters
+//
+// the mysql driver only supports positional parameters, so named parameters are rejected since their
+// arguments could not get bound by name.
+//
+// **Parameters**
+//   - function:   function to evaluate
+//   - parameters: parameters already written to the command
+//   - command:    command to write evaluation result to
+//
+// **Returns**
+//   - error: error if parameter can not be represented in database
+func (info *MySQLInfo) EvaluateParameter(parameter *xpr.ParameterNode, parameters *Parameters, command *strings.Builder) error {
+	if len(parameter.Name()) > 0 {
+		return fmt.Errorf("Named parameter '%s' is not supported by mysql, use positional parameters instead", parameter.Name())
+	}
+
+	command.WriteString("?")
+	return nil
+}
+
+// MaskColumn masks a column for use in an sql statement
+//
+// **Parameters**
+//   - name: name of column to mask
+//
+// **Returns**
+//   - string: masked column name
+func (info *MySQLInfo) MaskColumn(name string) string {
+	return fmt.Sprintf("`%s`", name)
+}
+
+// EvaluateFunction evaluates representation of a function in database
+//
+// **Parameters**
+//   - function: function to evaluate
+//   - command: command to write evaluation result to
+func (info *MySQLInfo) EvaluateFunction(function *xpr.FunctionNode, command *strings.Builder, eval func(interface{}) error) error {
+	_, err := EvaluateFunction(function, command, eval)
+	return err
+}
+
+// EvaluateOrder evaluates representation of an order criteria in database
+//
+// **Parameters**
+//   - order:   order criteria to evaluate
+//   - command: command to write evaluation result to
+//   - eval:    function used to evaluate expressions
+func (info *MySQLInfo) EvaluateOrder(order *xpr.OrderNode, command *strings.Builder, eval func(interface{}) error) error {
+	return EvaluateOrder(order, command, eval, false)
+}
+
+// EvaluateJoin evaluates representation of a join operator in database
+//
+// **Parameters**
+//   - jointype: type of join to evaluate
+//   - command:  command to write evaluation result to
+//
+// **Returns**
+//   - error: error if database does not support the join type
+func (info *MySQLInfo) EvaluateJoin(jointype xpr.JoinType, command *strings.Builder) error {
+	if jointype == xpr.JoinFull {
+		return fmt.Errorf("MySQL does not support FULL OUTER JOIN")
+	}
+
+	return EvaluateJoin(jointype, command)
+}
+
+// EvaluateWith evaluates representation of the keyword introducing common table expressions
+//
+// **Parameters**
+//   - recursive: determines whether any of the common table expressions is recursive
+//   - command:   command to write evaluation result to
+func (info *MySQLInfo) EvaluateWith(recursive bool, command *strings.Builder) {
+	EvaluateWith(recursive, command)
+}
+
+// EvaluateLimit evaluates representation of a row limit in database
+//
+// **Parameters**
+//   - limit:   expression specifying maximum number of rows to return, nil if number of rows is not limited
+//   - offset:  expression specifying number of rows to skip, nil if no rows are skipped
+//   - ordered: determines whether the command already contains an ORDER BY clause
+//   - command: command to write evaluation result to
+//   - eval:    function used to evaluate expressions
+func (info *MySQLInfo) EvaluateLimit(limit interface{}, offset interface{}, ordered bool, command *strings.Builder, eval func(interface{}) error) error {
+	// mysql only supports an offset in combination with a limit
+	if limit != nil || offset != nil {
+		command.WriteString(" LIMIT ")
+		if limit != nil {
+			err := eval(limit)
+			if err != nil {
+				return err
+			}
+		} else {
+			command.WriteString("18446744073709551615")
+		}
+	}
+
+	if offset != nil {
+		command.WriteString(" OFFSET ")
+		return eval(offset)
+	}
+
+	return nil
+}
+
+// ExistsTableOrView determines whether a table exists in database
+//
+// **Parameters**
+//   - connection: connection or transaction used to query the database
+//   - name: name of table or view
+//
+// **Returns**
+//   - bool: true if table or view exists, false otherwise
+func (info *MySQLInfo) ExistsTableOrView(connection interfaces.IExecutor, name string) (bool, error) {
+	rows, err := connection.QueryContext(context.Background(), "SELECT TABLE_NAME FROM information_schema.TABLES WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ?", name)
+	if err != nil {
+		return false, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// GetDatabaseType get type used in database
+//
+// **Parameters**
+//   - type: application data type
+//
+// **Returns**
+//   - string: database type name
+func (info *MySQLInfo) GetDatabaseType(datatype reflect.Type) string {
+	datatype = models.UnderlyingType(datatype)
+
+	switch datatype.Kind() {
+	case reflect.Bool:
+		return "BOOLEAN"
+	case reflect.String:
+		return "TEXT"
+	case reflect.Int8:
+		return "TINYINT"
+	case reflect.Int16:
+		return "SMALLINT"
+	case reflect.Int32:
+		return "INT"
+	case reflect.Int, reflect.Int64:
+		return "BIGINT"
+	case reflect.Uint8:
+		return "TINYINT UNSIGNED"
+	case reflect.Uint16:
+		return "SMALLINT UNSIGNED"
+	case reflect.Uint32:
+		return "INT UNSIGNED"
+	case reflect.Uint, reflect.Uint64:
+		return "BIGINT UNSIGNED"
+	case reflect.Float32:
+		return "FLOAT"
+	case reflect.Float64:
+		return "DOUBLE"
+	case reflect.Slice, reflect.Array:
+		return "BLOB"
+	case reflect.Struct:
+		if datatype == reflect.TypeOf(time.Time{}) {
+			return "DATETIME"
+		}
+		return "TEXT"
+	default:
+		return "TEXT"
+	}
+}
+
+// CreateColumn creates sql text to use when creating a column
+//
+// **Parameters**
+//   - column:  column to create
+//   - command: command builder string
+func (info *MySQLInfo) CreateColumn(column *models.ColumnDescriptor, command *strings.Builder) {
+	command.WriteString(info.MaskColumn(column.Name()))
+
+	datatype := info.GetDatabaseType(column.DataType())
+	if datatype == "TEXT" {
+		// TEXT columns can't be used in keys without a prefix length
+		// so a VARCHAR is used if a size is known or a key has to be created
+		if column.Size() > 0 {
+			datatype = fmt.Sprintf("VARCHAR(%d)", column.Size())
+		} else if column.IsPrimaryKey() || column.IsUnique() {
+			datatype = "VARCHAR(255)"
+		}
+	}
+	command.WriteString(fmt.Sprintf(" %s", datatype))
+
+	if column.IsPrimaryKey() {
+		command.WriteRune(' ')
+		command.WriteString("PRIMARY KEY")
+	}
+
+	if column.IsAutoIncrement() {
+		command.WriteRune(' ')
+		command.WriteString("AUTO_INCREMENT")
+	}
+
+	if column.IsUnique() {
+		command.WriteRune(' ')
+		command.WriteString("UNIQUE")
+	}
+
+	if column.IsNotNull() {
+		command.WriteRune(' ')
+		command.WriteString("NOT NULL")
+	}
+
+	if column.DefaultValue() != "" {
+		command.WriteString(" DEFAULT ")
+		command.WriteString(column.DefaultValue())
+	}
+}
+
+// AddColumn creates sql text to use when adding a column to an existing table
+//
+// **Parameters**
+//   - table:   name of table to add column to
+//   - column:  column to add
+//   - command: command builder string
+func (info *MySQLInfo) AddColumn(table string, column *models.ColumnDescriptor, command *strings.Builder) {
+	command.WriteString("ALTER TABLE ")
+	command.WriteString(table)
+	command.WriteString(" ADD COLUMN ")
+	info.CreateColumn(column, command)
+}
+
+// DropIndex creates sql text to use when removing an index
+//
+// mysql does not support to check for existence of an index when dropping it, so nothing is written if ifexists
+// is set. Existing indices are provided by GetSchema which reads information_schema.STATISTICS, indices found
+// there are to be dropped without ifexists.
+//
+// **Parameters**
+//   - table:    name of table containing index
+//   - name:     name of index to remove
+//   - ifexists: only remove index if it exists
+//   - command:  command builder string
+func (info *MySQLInfo) DropIndex(table string, name string, ifexists bool, command *strings.Builder) {
+	if ifexists {
+		return
+	}
+
+	command.WriteString("DROP INDEX ")
+	command.WriteString(name)
+	command.WriteString(" ON ")
+	command.WriteString(table)
+}
+
+// RenameTable creates sql text to use when renaming a table
+//
+// **Parameters**
+//   - oldname: current name of table
+//   - newname: name to rename table to
+//   - command: command builder string
+func (info *MySQLInfo) RenameTable(oldname string, newname string, command *strings.Builder) {
+	command.WriteString("ALTER TABLE ")
+	command.WriteString(oldname)
+	command.WriteString(" RENAME TO ")
+	command.WriteString(newname)
+}
+
+func (info *MySQLInfo) toDatabaseType(datatype string) string {
+	datatype = strings.ToUpper(datatype)
+	if datatype == "TINYINT(1)" {
+		return "BOOLEAN"
+	}
+
+	// display width of integer types is irrelevant for the stored data
+	return mysqlintegertype.ReplaceAllString(datatype, "$1")
+}
+
+func (info *MySQLInfo) analyseIndices(connection interfaces.IExecutor, tablename string) (map[string]bool, map[string]bool, []*models.IndexDescriptor, []*models.IndexDescriptor, error) {
+	rows, err := connection.QueryContext(context.Background(), "SELECT INDEX_NAME, NON_UNIQUE, COLUMN_NAME FROM information_schema.STATISTICS WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? ORDER BY INDEX_NAME, SEQ_IN_INDEX", tablename)
+	if err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("Unable to load indices: %w", err)
+	}
+	defer rows.Close()
+
+	primarykeys := make(map[string]bool)
+	var uniquenames []string
+	uniquecolumns := make(map[string][]string)
+	var indexnames []string
+	indexcolumns := make(map[string][]string)
+	for rows.Next() {
+		var name string
+		var nonunique int
+		var column string
+
+		err = rows.Scan(&name, &nonunique, &column)
+		if err != nil {
+			return nil, nil, nil, nil, fmt.Errorf("Error scanning index data: %w", err)
+		}
+
+		switch {
+		case name == "PRIMARY":
+			primarykeys[column] = true
+		case nonunique == 0:
+			if _, exists := uniquecolumns[name]; !exists {
+				uniquenames = append(uniquenames, name)
+			}
+			uniquecolumns[name] = append(uniquecolumns[name], column)
+		default:
+			if _, exists := indexcolumns[name]; !exists {
+				indexnames = append(indexnames, name)
+			}
+			indexcolumns[name] = append(indexcolumns[name], column)
+		}
+	}
+
+	unique := make(map[string]bool)
+	var uniques []*models.IndexDescriptor
+	for _, name := range uniquenames {
+		columns := uniquecolumns[name]
+		if len(columns) == 1 {
+			unique[columns[0]] = true
+		} else {
+			uniques = append(uniques, models.NewIndexDescriptor("", columns...))
+		}
+	}
+
+	prefix := fmt.Sprintf("idx_%s_", tablename)
+	var indices []*models.IndexDescriptor
+	for _, name := range indexnames {
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+
+		indices = append(indices, models.NewIndexDescriptor(name[len(prefix):], indexcolumns[name]...))
+	}
+
+	return primarykeys, unique, indices, uniques, nil
+}
+
+func (info *MySQLInfo) analyseTable(connection interfaces.IExecutor, tablename string) (*models.Table, error) {
+	primarykeys, unique, indices, uniques, err := info.analyseIndices(connection, tablename)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := connection.QueryContext(context.Background(), "SELECT COLUMN_NAME, COLUMN_TYPE, IS_NULLABLE, COLUMN_DEFAULT, EXTRA FROM information_schema.COLUMNS WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? ORDER BY ORDINAL_POSITION", tablename)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to load columns: %w", err)
+	}
+	defer rows.Close()
+
+	var columns []*models.ColumnDescriptor
+	for rows.Next() {
+		var name string
+		var datatype string
+		var nullable string
+		var defaultvalue sql.NullString
+		var extra string
+
+		err = rows.Scan(&name, &datatype, &nullable, &defaultvalue, &extra)
+		if err != nil {
+			return nil, fmt.Errorf("Error scanning column data: %w", err)
+		}
+
+		isautoincrement := strings.Contains(strings.ToLower(extra), "auto_increment")
+
+		// primary keys are implicitly not null
+		isnotnull := nullable == "NO" && !primarykeys[name]
+
+		columns = append(columns, models.NewSchemaColumn(name, info.toDatabaseType(datatype), primarykeys[name], isautoincrement, unique[name], isnotnull, defaultvalue.String))
+	}
+
+	return models.NewTableDescriptor(tablename, columns, indices, uniques), nil
+}
+
+// GetSchema get schema of a table or view in database
+//
+// **Parameters**
+//   - connection: connection or transaction used to query the database
+//   - name: name of table or view
+//
+// **Returns**
+//   - *Schema: schema information retrieved from database
+//   - error: error information if any error occured
+func (info *MySQLInfo) GetSchema(connection interfaces.IExecutor, name string) (models.Schema, error) {
+	row := connection.QueryRowContext(context.Background(), "SELECT TABLE_TYPE FROM information_schema.TABLES WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ?", name)
+
+	var typename string
+	err := row.Scan(&typename)
+	if err != nil {
+		return nil, fmt.Errorf("Error scanning table data: %w", err)
+	}
+
+	schema, err := info.toSchema(connection, typename, name)
+	if err != nil {
+		return nil, fmt.Errorf("Error converting schema row: %w", err)
+	}
+
+	return schema, nil
+}
+
+func (info *MySQLInfo) toSchema(connection interfaces.IExecutor, typename string, tablename string) (models.Schema, error) {
+	switch typename {
+	case "BASE TABLE":
+		return info.analyseTable(connection, tablename)
+	case "VIEW":
+		row := connection.QueryRowContext(context.Background(), "SELECT VIEW_DEFINITION FROM information_schema.VIEWS WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ?", tablename)
+
+		var sql string
+		err := row.Scan(&sql)
+		if err != nil {
+			return nil, fmt.Errorf("Error scanning view data: %w", err)
+		}
+
+		return &models.View{
+			Name: tablename,
+			SQL:  sql}, nil
+	default:
+		return nil, fmt.Errorf("Unsupported table type '%s'", typename)
+	}
+}
+
+func (info *MySQLInfo) loadSchemas(connection interfaces.IExecutor) ([]*SchemaModel, error) {
+	rows, err := connection.QueryContext(context.Background(), "SELECT TABLE_TYPE, TABLE_NAME FROM information_schema.TABLES WHERE TABLE_SCHEMA = DATABASE()")
+	if err != nil {
+		return nil, fmt.Errorf("Unable to retrieve schema infos: %w", err)
+	}
+
+	defer rows.Close()
+
+	var schemas []*SchemaModel
+	for rows.Next() {
+		var typename string
+		var tablename string
+
+		err := rows.Scan(&typename, &tablename)
+		if err != nil {
+			return nil, fmt.Errorf("Error scanning table data: %w", err)
+		}
+
+		schemas = append(schemas, &SchemaModel{
+			SchemaType: typename,
+			TableName:  tablename})
+	}
+
+	return schemas, nil
+}
+
+// GetSchemas get all schemas in database
+//
+// **Parameters**
+//   - connection: connection of which to retrieve schematas
+//
+// **Returns**
+//   - []Schema: schemas in database
+//   - error   : errors if any occured
+func (info *MySQLInfo) GetSchemas(connection interfaces.IExecutor) ([]models.Schema, error) {
+	schemas, err := info.loadSchemas(connection)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to load schema information: %w", err)
+	}
+
+	var result []models.Schema
+
+	for _, schemainfo := range schemas {
+		schema, err := info.toSchema(connection, schemainfo.SchemaType, schemainfo.TableName)
+		if err != nil {
+			return nil, fmt.Errorf("Error creating schema: %w", err)
+		}
+
+		result = append(result, schema)
+	}
+
+	return result, nil
+}
+
+// EvaluateUpsert evaluates representation of an insert operation which updates existing rows on conflict
+//
+// **Parameters**
+//   - upsert:  upsert operation to evaluate
+//   - command: command to write evaluation result to
+//
+// **Returns**
+//   - error: error if upsert can not be represented in database
+func (info *MySQLInfo) EvaluateUpsert(upsert *Upsert, command *strings.Builder) error {
+	if len(upsert.Conflict) == 0 {
+		return fmt.Errorf("Upsert needs at least one conflict column")
+	}
+
+	// mysql detects conflicts using all unique keys of the table, so conflict columns are not part of the command
+	err := writeUpsertInsert(upsert, info, command)
+	if err != nil {
+		return err
+	}
+
+	command.WriteString(" ON DUPLICATE KEY UPDATE ")
+
+	if len(upsert.Update) == 0 {
+		// assigning a column to itself leaves the existing row untouched
+		command.WriteString(info.MaskColumn(upsert.Conflict[0]))
+		command.WriteString(" = ")
+		command.WriteString(info.MaskColumn(upsert.Conflict[0]))
+		return nil
+	}
+
+	for index, column := range upsert.Update {
+		if index > 0 {
+			command.WriteRune(',')
+		}
+
+		command.WriteString(info.MaskColumn(column))
+		command.WriteString(" = VALUES(")
+		command.WriteString(info.MaskColumn(column))
+		command.WriteRune(')')
+	}
+	return nil
+}
+
+// EvaluateReturning evaluates representation of fields returned by a data modification command
+//
+// **Parameters**
+//   - position: position in command which is currently written
+//   - deleted:  determines whether command deletes rows, so fields of deleted rows are returned
+//   - fields:   expressions specifying fields to return
+//   - command:  command to write evaluation result to
+//   - eval:     function used to evaluate expressions
+//
+// **Returns**
+//   - error: error if database does not support to return fields
+func (info *MySQLInfo) EvaluateReturning(position ReturningPosition, deleted bool, fields []interface{}, command *strings.Builder, eval func(interface{}) error) error {
+	return fmt.Errorf("MySQL does not support to return fields of modified rows")
+}
+
+// EvaluateSavepoint evaluates representation of an operation on a savepoint of a transaction
+//
+// **Parameters**
+//   - operation: operation to evaluate
+//   - name:      name of savepoint
+//   - command:   command to write evaluation result to
+//
+// **Returns**
+//   - error: error if database does not support the operation
+func (info *MySQLInfo) EvaluateSavepoint(operation SavepointOperation, name string, command *strings.Builder) error {
+	return EvaluateSavepoint(operation, name, command)
+}
+
+// IsRetryable determines whether an error is caused by a conflict with a concurrent transaction
+//
+// **Parameters**
+//   - err: error to evaluate
+//
+// **Returns**
+//   - bool: true if operation which caused the error can get retried, false otherwise
+func (info *MySQLInfo) IsRetryable(err error) bool {
+	// ER_LOCK_DEADLOCK and ER_LOCK_WAIT_TIMEOUT
+	return hasErrorCode(err, "Number", 1213, 1205)
+}
+
+// MaxBatchRows maximum number of rows which can be inserted using a single statement
+//
+// **Parameters**
+//   - columns: number of columns filled per row
+//
+// **Returns**
+//   - int: maximum number of rows per statement, 0 if a single row exceeds the number of supported parameters
+func (info *MySQLInfo) MaxBatchRows(columns int) int {
+	return MaxBatchRows(columns, 65535)
+}
+
+// ReturnIdentity adds a statement to command which returns identity of last inserted row
+//
+// **Parameters**
+//   - identity: column containing the identity of the row
+//   - command:  statement to modify
+//
+// **Returns**
+//   - bool:  true if the command returns the identity as result row, false if the identity is provided by the execution result
+//   - error: error if the identity can not get returned by the database
+func (info *MySQLInfo) ReturnIdentity(identity *models.ColumnDescriptor, command *strings.Builder) (bool, error) {
+	// the driver provides the generated id as last insert id of the execution result
+	return false, nil
+}
@@ -0,0 +1,28 @@
+package connection
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMySQLDatabaseTypes(t *testing.T) {
+	connectioninfo := NewMySQLInfo()
+
+	assert.Equal(t, "BOOLEAN", connectioninfo.GetDatabaseType(reflect.TypeOf(true)))
+	assert.Equal(t, "INT", connectioninfo.GetDatabaseType(reflect.TypeOf(int32(0))))
+	assert.Equal(t, "BIGINT UNSIGNED", connectioninfo.GetDatabaseType(reflect.TypeOf(uint64(0))))
+	assert.Equal(t, "DOUBLE", connectioninfo.GetDatabaseType(reflect.TypeOf(0.0)))
+	assert.Equal(t, "TEXT", connectioninfo.GetDatabaseType(reflect.TypeOf("")))
+	assert.Equal(t, "BLOB", connectioninfo.GetDatabaseType(reflect.TypeOf([]byte{})))
+	assert.Equal(t, "DATETIME", connectioninfo.GetDatabaseType(reflect.TypeOf(time.Time{})))
+	assert.Equal(t, "BIGINT", connectioninfo.GetDatabaseType(reflect.TypeOf((*int64)(nil))))
+	assert.Equal(t, "BIGINT", connectioninfo.GetDatabaseType(reflect.TypeOf(sql.NullInt64{})))
+	assert.Equal(t, "DATETIME", connectioninfo.GetDatabaseType(reflect.TypeOf(sql.NullTime{})))
+	assert.Equal(t, "TEXT", connectioninfo.GetDatabaseType(reflect.TypeOf(testUUID{})))
+	assert.Equal(t, "TEXT", connectioninfo.GetDatabaseType(reflect.TypeOf(testNullUUID{})))
+	assert.Equal(t, "TEXT", connectioninfo.GetDatabaseType(reflect.TypeOf(testPoint{})))
+}
@@ -0,0 +1,51 @@
+package connection
+
+import "strconv"
+
+// Parameters keeps track of the parameters written to a command. Used by databases which number their parameters
+//            to assign indices without having to analyse the command written so far.
+type Parameters struct {
+	count   int            // highest index assigned to a parameter
+	indices map[string]int // indices assigned to named parameters
+}
+
+// NewParameters creates a new parameter state for a command
+//
+// **Returns**
+//   - *Parameters: parameter state without any parameters
+func NewParameters() *Parameters {
+	return &Parameters{
+		indices: make(map[string]int)}
+}
+
+// Index provides the index of a parameter. Unnamed parameters get the index following the highest index
+//       already assigned. Named parameters get the same index every time they are used in a command.
+//       Parameters named by a number use the number as index.
+//
+// **Parameters**
+//   - name: name of parameter, empty for unnamed parameters
+//
+// **Returns**
+//   - int: index of parameter starting with 1
+func (parameters *Parameters) Index(name string) int {
+	if len(name) == 0 {
+		parameters.count++
+		return parameters.count
+	}
+
+	if index, ok := parameters.indices[name]; ok {
+		return index
+	}
+
+	index, err := strconv.Atoi(name)
+	if err != nil {
+		index = parameters.count + 1
+	}
+
+	if index > parameters.count {
+		parameters.count = index
+	}
+
+	parameters.indices[name] = index
+	return index
+}
@@ -0,0 +1,19 @@
+package connection
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParameterIndices(t *testing.T) {
+	parameters := NewParameters()
+
+	require.Equal(t, 1, parameters.Index(""))
+	require.Equal(t, 2, parameters.Index("name"))
+	require.Equal(t, 3, parameters.Index(""))
+	require.Equal(t, 2, parameters.Index("name"))
+	require.Equal(t, 7, parameters.Index("7"))
+	require.Equal(t, 8, parameters.Index(""))
+	require.Equal(t, 1, parameters.Index("1"))
+}
@@ -0,0 +1,590 @@
+package connection
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/verticalgmbh/database-go/entities/models"
+	"github.com/verticalgmbh/database-go/interfaces"
+	"github.com/verticalgmbh/database-go/xpr"
+)
+
+// PostgresInfo - postgres specific information
+type PostgresInfo struct {
+}
+
+// NewPostgresInfo creates a new postgres info
+//
+// **Returns**
+//   - *PostgresInfo: created postgres connection info
+func NewPostgresInfo() *PostgresInfo {
+	return &PostgresInfo{}
+}
+
+// EvaluateParameter - literal used to specify parameters
+//
+// postgres only supports indexed parameters. Named parameters are written as is if their name is an index,
+// all other parameters get the next index following the highest index already assigned. Named parameters
+// used multiple times get the same index every time.
+//
+// **Parameters**
+//   - function:   function to evaluate
+//   - parameters: parameters already written to the command
+//   - command:    command to write evaluation result to
+//
+// **Returns**
+//   - error: error if parameter can not be represented in database
+func (info *PostgresInfo) EvaluateParameter(parameter *xpr.ParameterNode, parameters *Parameters, command *strings.Builder) error {
+	command.WriteRune('$')
+	command.WriteString(strconv.Itoa(parameters.Index(parameter.Name())))
+	return nil
+}
+
+// MaskColumn masks a column for use in an sql statement
+//
+// **Parameters**
+//   - name: name of column to mask
+//
+// **Returns**
+//   - string: masked column name
+func (info *PostgresInfo) MaskColumn(name string) string {
+	return fmt.Sprintf("\"%s\"", name)
+}
+
+// EvaluateFunction evaluates representation of a function in database
+//
+// **Parameters**
+//   - function: function to evaluate
+//   - command: command to write evaluation result to
+func (info *PostgresInfo) EvaluateFunction(function *xpr.FunctionNode, command *strings.Builder, eval func(interface{}) error) error {
+	_, err := EvaluateFunction(function, command, eval)
+	return err
+}
+
+// EvaluateOrder evaluates representation of an order criteria in database
+//
+// **Parameters**
+//   - order:   order criteria to evaluate
+//   - command: command to write evaluation result to
+//   - eval:    function used to evaluate expressions
+func (info *PostgresInfo) EvaluateOrder(order *xpr.OrderNode, command *strings.Builder, eval func(interface{}) error) error {
+	return EvaluateOrder(order, command, eval, true)
+}
+
+// EvaluateJoin evaluates representation of a join operator in database
+//
+// **Parameters**
+//   - jointype: type of join to evaluate
+//   - command:  command to write evaluation result to
+//
+// **Returns**
+//   - error: error if database does not support the join type
+func (info *PostgresInfo) EvaluateJoin(jointype xpr.JoinType, command *strings.Builder) error {
+	return EvaluateJoin(jointype, command)
+}
+
+// EvaluateWith evaluates representation of the keyword introducing common table expressions
+//
+// **Parameters**
+//   - recursive: determines whether any of the common table expressions is recursive
+//   - command:   command to write evaluation result to
+func (info *PostgresInfo) EvaluateWith(recursive bool, command *strings.Builder) {
+	EvaluateWith(recursive, command)
+}
+
+// EvaluateLimit evaluates representation of a row limit in database
+//
+// **Parameters**
+//   - limit:   expression specifying maximum number of rows to return, nil if number of rows is not limited
+//   - offset:  expression specifying number of rows to skip, nil if no rows are skipped
+//   - ordered: determines whether the command already contains an ORDER BY clause
+//   - command: command to write evaluation result to
+//   - eval:    function used to evaluate expressions
+func (info *PostgresInfo) EvaluateLimit(limit interface{}, offset interface{}, ordered bool, command *strings.Builder, eval func(interface{}) error) error {
+	if limit != nil {
+		command.WriteString(" LIMIT ")
+		err := eval(limit)
+		if err != nil {
+			return err
+		}
+	}
+
+	if offset != nil {
+		command.WriteString(" OFFSET ")
+		return eval(offset)
+	}
+
+	return nil
+}
+
+// ExistsTableOrView determines whether a table exists in database
+//
+// **Parameters**
+//   - connection: connection or transaction used to query the database
+//   - name: name of table or view
+//
+// **Returns**
+//   - bool: true if table or view exists, false otherwise
+func (info *PostgresInfo) ExistsTableOrView(connection interfaces.IExecutor, name string) (bool, error) {
+	rows, err := connection.QueryContext(context.Background(), "SELECT table_name FROM information_schema.tables WHERE table_schema = current_schema() AND table_name = $1", name)
+	if err != nil {
+		return false, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// GetDatabaseType get type used in database
+//
+// **Parameters**
+//   - type: application data type
+//
+// **Returns**
+//   - string: database type name
+func (info *PostgresInfo) GetDatabaseType(datatype reflect.Type) string {
+	datatype = models.UnderlyingType(datatype)
+
+	switch datatype.Kind() {
+	case reflect.Bool:
+		return "BOOLEAN"
+	case reflect.String:
+		return "TEXT"
+	case reflect.Int8, reflect.Int16, reflect.Uint8:
+		return "SMALLINT"
+	case reflect.Int32, reflect.Uint16:
+		return "INTEGER"
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return "BIGINT"
+	case reflect.Float32:
+		return "REAL"
+	case reflect.Float64:
+		return "DOUBLE PRECISION"
+	case reflect.Slice, reflect.Array:
+		return "BYTEA"
+	case reflect.Struct:
+		if datatype == reflect.TypeOf(time.Time{}) {
+			return "TIMESTAMPTZ"
+		}
+		return "TEXT"
+	default:
+		return "TEXT"
+	}
+}
+
+// CreateColumn creates sql text to use when creating a column
+//
+// **Parameters**
+//   - column:  column to create
+//   - command: command builder string
+func (info *PostgresInfo) CreateColumn(column *models.ColumnDescriptor, command *strings.Builder) {
+	command.WriteString(info.MaskColumn(column.Name()))
+
+	datatype := info.GetDatabaseType(column.DataType())
+	if column.IsAutoIncrement() {
+		// postgres has no autoincrement flag but serial types which are backed by a sequence
+		switch datatype {
+		case "SMALLINT":
+			datatype = "SMALLSERIAL"
+		case "INTEGER":
+			datatype = "SERIAL"
+		default:
+			datatype = "BIGSERIAL"
+		}
+	}
+	command.WriteString(fmt.Sprintf(" %s", datatype))
+
+	if column.IsPrimaryKey() {
+		command.WriteRune(' ')
+		command.WriteString("PRIMARY KEY")
+	}
+
+	if column.IsUnique() {
+		command.WriteRune(' ')
+		command.WriteString("UNIQUE")
+	}
+
+	if column.IsNotNull() {
+		command.WriteRune(' ')
+		command.WriteString("NOT NULL")
+	}
+
+	if column.DefaultValue() != "" {
+		command.WriteString(" DEFAULT ")
+		command.WriteString(column.DefaultValue())
+	}
+}
+
+func (info *PostgresInfo) toDatabaseType(datatype string) string {
+	datatype = strings.ToUpper(datatype)
+	switch datatype {
+	case "TIMESTAMP WITH TIME ZONE":
+		return "TIMESTAMPTZ"
+	case "CHARACTER VARYING":
+		return "VARCHAR"
+	default:
+		return datatype
+	}
+}
+
+// AddColumn creates sql text to use when adding a column to an existing table
+//
+// **Parameters**
+//   - table:   name of table to add column to
+//   - column:  column to add
+//   - command: command builder string
+func (info *PostgresInfo) AddColumn(table string, column *models.ColumnDescriptor, command *strings.Builder) {
+	command.WriteString("ALTER TABLE ")
+	command.WriteString(table)
+	command.WriteString(" ADD COLUMN ")
+	info.CreateColumn(column, command)
+}
+
+// DropIndex creates sql text to use when removing an index
+//
+// **Parameters**
+//   - table:    name of table containing index
+//   - name:     name of index to remove
+//   - ifexists: only remove index if it exists
+//   - command:  command builder string
+func (info *PostgresInfo) DropIndex(table string, name string, ifexists bool, command *strings.Builder) {
+	command.WriteString("DROP INDEX ")
+	if ifexists {
+		command.WriteString("IF EXISTS ")
+	}
+	command.WriteString(name)
+}
+
+// RenameTable creates sql text to use when renaming a table
+//
+// **Parameters**
+//   - oldname: current name of table
+//   - newname: name to rename table to
+//   - command: command builder string
+func (info *PostgresInfo) RenameTable(oldname string, newname string, command *strings.Builder) {
+	command.WriteString("ALTER TABLE ")
+	command.WriteString(oldname)
+	command.WriteString(" RENAME TO ")
+	command.WriteString(newname)
+}
+
+func (info *PostgresInfo) analyseColumns(connection interfaces.IExecutor, tablename string) ([]*models.ColumnDescriptor, []*models.IndexDescriptor, error) {
+	rows, err := connection.QueryContext(context.Background(), "SELECT tc.constraint_name, tc.constraint_type, kcu.column_name FROM information_schema.table_constraints tc INNER JOIN information_schema.key_column_usage kcu ON kcu.constraint_schema = tc.constraint_schema AND kcu.constraint_name = tc.constraint_name WHERE tc.table_schema = current_schema() AND tc.table_name = $1 AND tc.constraint_type IN ('PRIMARY KEY', 'UNIQUE') ORDER BY tc.constraint_name, kcu.ordinal_position", tablename)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Unable to load constraints: %w", err)
+	}
+	defer rows.Close()
+
+	primarykeys := make(map[string]bool)
+	var constraintnames []string
+	constraints := make(map[string][]string)
+	for rows.Next() {
+		var name string
+		var constrainttype string
+		var column string
+
+		err = rows.Scan(&name, &constrainttype, &column)
+		if err != nil {
+			return nil, nil, fmt.Errorf("Error scanning constraint data: %w", err)
+		}
+
+		if constrainttype == "PRIMARY KEY" {
+			primarykeys[column] = true
+			continue
+		}
+
+		if _, exists := constraints[name]; !exists {
+			constraintnames = append(constraintnames, name)
+		}
+		constraints[name] = append(constraints[name], column)
+	}
+
+	uniquecolumns := make(map[string]bool)
+	var uniques []*models.IndexDescriptor
+	for _, name := range constraintnames {
+		columns := constraints[name]
+		if len(columns) == 1 {
+			uniquecolumns[columns[0]] = true
+		} else {
+			uniques = append(uniques, models.NewIndexDescriptor("", columns...))
+		}
+	}
+
+	columnrows, err := connection.QueryContext(context.Background(), "SELECT column_name, data_type, is_nullable, column_default FROM information_schema.columns WHERE table_schema = current_schema() AND table_name = $1 ORDER BY ordinal_position", tablename)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Unable to load columns: %w", err)
+	}
+	defer columnrows.Close()
+
+	var columns []*models.ColumnDescriptor
+	for columnrows.Next() {
+		var name string
+		var datatype string
+		var nullable string
+		var defaultvalue sql.NullString
+
+		err = columnrows.Scan(&name, &datatype, &nullable, &defaultvalue)
+		if err != nil {
+			return nil, nil, fmt.Errorf("Error scanning column data: %w", err)
+		}
+
+		isautoincrement := strings.HasPrefix(defaultvalue.String, "nextval(")
+		if isautoincrement {
+			defaultvalue.String = ""
+		}
+
+		// primary keys are implicitly not null
+		isnotnull := nullable == "NO" && !primarykeys[name]
+
+		columns = append(columns, models.NewSchemaColumn(name, info.toDatabaseType(datatype), primarykeys[name], isautoincrement, uniquecolumns[name], isnotnull, defaultvalue.String))
+	}
+
+	return columns, uniques, nil
+}
+
+func (info *PostgresInfo) analyseIndexDefinitions(connection interfaces.IExecutor, tablename string) ([]*models.IndexDescriptor, error) {
+	indexrows, err := connection.QueryContext(context.Background(), "SELECT indexname, indexdef FROM pg_indexes WHERE schemaname = current_schema() AND tablename = $1", tablename)
+	if err != nil {
+		return nil, err
+	}
+	defer indexrows.Close()
+
+	var indices []*models.IndexDescriptor
+
+	prefix := fmt.Sprintf("idx_%s_", tablename)
+	expression := regexp.MustCompile("\\((?P<columns>.+)\\)$")
+	for indexrows.Next() {
+		var indexname string
+		var indexsql string
+
+		err = indexrows.Scan(&indexname, &indexsql)
+		if err != nil {
+			return nil, fmt.Errorf("Error matching index sql: %w", err)
+		}
+
+		// indices backing primary keys and unique constraints are not managed as indices
+		if !strings.HasPrefix(indexname, prefix) {
+			continue
+		}
+
+		groups := expression.FindStringSubmatch(indexsql)
+		if groups == nil {
+			return nil, fmt.Errorf("Error matching index sql '%s'", indexsql)
+		}
+
+		var columns []string
+		for _, column := range strings.Split(groups[1], ",") {
+			columns = append(columns, strings.Trim(column, " \""))
+		}
+
+		indices = append(indices, models.NewIndexDescriptor(indexname[len(prefix):], columns...))
+	}
+
+	return indices, nil
+}
+
+// GetSchema get schema of a table or view in database
+//
+// **Parameters**
+//   - connection: connection or transaction used to query the database
+//   - name: name of table or view
+//
+// **Returns**
+//   - *Schema: schema information retrieved from database
+//   - error: error information if any error occured
+func (info *PostgresInfo) GetSchema(connection interfaces.IExecutor, name string) (models.Schema, error) {
+	row := connection.QueryRowContext(context.Background(), "SELECT table_type FROM information_schema.tables WHERE table_schema = current_schema() AND table_name = $1", name)
+
+	var typename string
+	err := row.Scan(&typename)
+	if err != nil {
+		return nil, fmt.Errorf("Error scanning table data: %w", err)
+	}
+
+	schema, err := info.toSchema(connection, typename, name)
+	if err != nil {
+		return nil, fmt.Errorf("Error converting schema row: %w", err)
+	}
+
+	return schema, nil
+}
+
+func (info *PostgresInfo) toSchema(connection interfaces.IExecutor, typename string, tablename string) (models.Schema, error) {
+	switch typename {
+	case "BASE TABLE":
+		columns, uniques, err := info.analyseColumns(connection, tablename)
+		if err != nil {
+			return nil, err
+		}
+
+		indices, err := info.analyseIndexDefinitions(connection, tablename)
+		if err != nil {
+			return nil, err
+		}
+
+		return models.NewTableDescriptor(tablename, columns, indices, uniques), nil
+	case "VIEW":
+		row := connection.QueryRowContext(context.Background(), "SELECT view_definition FROM information_schema.views WHERE table_schema = current_schema() AND table_name = $1", tablename)
+
+		var sql string
+		err := row.Scan(&sql)
+		if err != nil {
+			return nil, fmt.Errorf("Error scanning view data: %w", err)
+		}
+
+		return &models.View{
+			Name: tablename,
+			SQL:  sql}, nil
+	default:
+		return nil, fmt.Errorf("Unsupported table type '%s'", typename)
+	}
+}
+
+func (info *PostgresInfo) loadSchemas(connection interfaces.IExecutor) ([]*SchemaModel, error) {
+	rows, err := connection.QueryContext(context.Background(), "SELECT table_type, table_name FROM information_schema.tables WHERE table_schema = current_schema()")
+	if err != nil {
+		return nil, fmt.Errorf("Unable to retrieve schema infos: %w", err)
+	}
+
+	defer rows.Close()
+
+	var schemas []*SchemaModel
+	for rows.Next() {
+		var typename string
+		var tablename string
+
+		err := rows.Scan(&typename, &tablename)
+		if err != nil {
+			return nil, fmt.Errorf("Error scanning table data: %w", err)
+		}
+
+		schemas = append(schemas, &SchemaModel{
+			SchemaType: typename,
+			TableName:  tablename})
+	}
+
+	return schemas, nil
+}
+
+// GetSchemas get all schemas in database
+//
+// **Parameters**
+//   - connection: connection of which to retrieve schematas
+//
+// **Returns**
+//   - []Schema: schemas in database
+//   - error   : errors if any occured
+func (info *PostgresInfo) GetSchemas(connection interfaces.IExecutor) ([]models.Schema, error) {
+	schemas, err := info.loadSchemas(connection)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to load schema information: %w", err)
+	}
+
+	var result []models.Schema
+
+	for _, schemainfo := range schemas {
+		schema, err := info.toSchema(connection, schemainfo.SchemaType, schemainfo.TableName)
+		if err != nil {
+			return nil, fmt.Errorf("Error creating schema: %w", err)
+		}
+
+		result = append(result, schema)
+	}
+
+	return result, nil
+}
+
+// EvaluateUpsert evaluates representation of an insert operation which updates existing rows on conflict
+//
+// **Parameters**
+//   - upsert:  upsert operation to evaluate
+//   - command: command to write evaluation result to
+//
+// **Returns**
+//   - error: error if upsert can not be represented in database
+func (info *PostgresInfo) EvaluateUpsert(upsert *Upsert, command *strings.Builder) error {
+	return EvaluateOnConflict(upsert, info, command)
+}
+
+// EvaluateReturning evaluates representation of fields returned by a data modification command
+//
+// **Parameters**
+//   - position: position in command which is currently written
+//   - deleted:  determines whether command deletes rows, so fields of deleted rows are returned
+//   - fields:   expressions specifying fields to return
+//   - command:  command to write evaluation result to
+//   - eval:     function used to evaluate expressions
+//
+// **Returns**
+//   - error: error if database does not support to return fields
+func (info *PostgresInfo) EvaluateReturning(position ReturningPosition, deleted bool, fields []interface{}, command *strings.Builder, eval func(interface{}) error) error {
+	return EvaluateReturning(position, fields, command, eval)
+}
+
+// EvaluateSavepoint evaluates representation of an operation on a savepoint of a transaction
+//
+// **Parameters**
+//   - operation: operation to evaluate
+//   - name:      name of savepoint
+//   - command:   command to write evaluation result to
+//
+// **Returns**
+//   - error: error if database does not support the operation
+func (info *PostgresInfo) EvaluateSavepoint(operation SavepointOperation, name string, command *strings.Builder) error {
+	return EvaluateSavepoint(operation, name, command)
+}
+
+// IsRetryable determines whether an error is caused by a conflict with a concurrent transaction
+//
+// **Parameters**
+//   - err: error to evaluate
+//
+// **Returns**
+//   - bool: true if operation which caused the error can get retried, false otherwise
+func (info *PostgresInfo) IsRetryable(err error) bool {
+	// serialization_failure and deadlock_detected
+	return hasSQLState(err, "40001", "40P01")
+}
+
+// MaxBatchRows maximum number of rows which can be inserted using a single statement
+//
+// **Parameters**
+//   - columns: number of columns filled per row
+//
+// **Returns**
+//   - int: maximum number of rows per statement, 0 if a single row exceeds the number of supported parameters
+func (info *PostgresInfo) MaxBatchRows(columns int) int {
+	return MaxBatchRows(columns, 65535)
+}
+
+// ReturnIdentity adds a statement to command which returns identity of last inserted row
+//
+// **Parameters**
+//   - identity: column containing the identity of the row
+//   - command:  statement to modify
+//
+// **Returns**
+//   - bool:  true if the command returns the identity as result row, false if the identity is provided by the execution result
+//   - error: error if the identity can not get returned by the database
+func (info *PostgresInfo) ReturnIdentity(identity *models.ColumnDescriptor, command *strings.Builder) (bool, error) {
+	if identity == nil {
+		// lastval() would provide the value of any sequence used last in the session
+		return false, errors.New("Unable to return the identity of a model without autoincrement column")
+	}
+
+	command.WriteString(" RETURNING ")
+	command.WriteString(info.MaskColumn(identity.Name()))
+	return true, nil
+}
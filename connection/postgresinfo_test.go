@@ -0,0 +1,28 @@
+package connection
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostgresDatabaseTypes(t *testing.T) {
+	connectioninfo := NewPostgresInfo()
+
+	assert.Equal(t, "BOOLEAN", connectioninfo.GetDatabaseType(reflect.TypeOf(true)))
+	assert.Equal(t, "INTEGER", connectioninfo.GetDatabaseType(reflect.TypeOf(int32(0))))
+	assert.Equal(t, "BIGINT", connectioninfo.GetDatabaseType(reflect.TypeOf(int64(0))))
+	assert.Equal(t, "DOUBLE PRECISION", connectioninfo.GetDatabaseType(reflect.TypeOf(0.0)))
+	assert.Equal(t, "TEXT", connectioninfo.GetDatabaseType(reflect.TypeOf("")))
+	assert.Equal(t, "BYTEA", connectioninfo.GetDatabaseType(reflect.TypeOf([]byte{})))
+	assert.Equal(t, "TIMESTAMPTZ", connectioninfo.GetDatabaseType(reflect.TypeOf(time.Time{})))
+	assert.Equal(t, "BIGINT", connectioninfo.GetDatabaseType(reflect.TypeOf((*int64)(nil))))
+	assert.Equal(t, "BIGINT", connectioninfo.GetDatabaseType(reflect.TypeOf(sql.NullInt64{})))
+	assert.Equal(t, "TIMESTAMPTZ", connectioninfo.GetDatabaseType(reflect.TypeOf(sql.NullTime{})))
+	assert.Equal(t, "TEXT", connectioninfo.GetDatabaseType(reflect.TypeOf(testUUID{})))
+	assert.Equal(t, "TEXT", connectioninfo.GetDatabaseType(reflect.TypeOf(testNullUUID{})))
+	assert.Equal(t, "TEXT", connectioninfo.GetDatabaseType(reflect.TypeOf(testPoint{})))
+}
@@ -0,0 +1,49 @@
+package connection
+
+import (
+	"fmt"
+	"sync"
+)
+
+// DialectFactory creates driver specific information for a database driver
+type DialectFactory func() IConnectionInfo
+
+var dialectlock sync.RWMutex
+var dialects = map[string]DialectFactory{
+	"sqlite3":   func() IConnectionInfo { return NewSqliteInfo() },
+	"postgres":  func() IConnectionInfo { return NewPostgresInfo() },
+	"pgx":       func() IConnectionInfo { return NewPostgresInfo() },
+	"mysql":     func() IConnectionInfo { return NewMySQLInfo() },
+	"sqlserver": func() IConnectionInfo { return NewSQLServerInfo() }}
+
+// Register registers a dialect for an sql driver. An existing registration for the driver is replaced.
+//
+// **Parameters**
+//   - drivername: name of sql driver as used in sql.Open
+//   - factory:    factory used to create driver specific information
+func Register(drivername string, factory DialectFactory) {
+	dialectlock.Lock()
+	defer dialectlock.Unlock()
+
+	dialects[drivername] = factory
+}
+
+// Resolve creates driver specific information for an sql driver
+//
+// **Parameters**
+//   - drivername: name of sql driver as used in sql.Open
+//
+// **Returns**
+//   - IConnectionInfo: driver specific information
+//   - error:           error if no dialect is registered for the driver
+func Resolve(drivername string) (IConnectionInfo, error) {
+	dialectlock.RLock()
+	factory, ok := dialects[drivername]
+	dialectlock.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("No dialect registered for sql driver '%s'. Use connection.Register to register one", drivername)
+	}
+
+	return factory(), nil
+}
@@ -0,0 +1,28 @@
+package connection
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveDefaultDialect(t *testing.T) {
+	info, err := Resolve("sqlite3")
+	require.NoError(t, err)
+	require.IsType(t, &SqliteInfo{}, info)
+}
+
+func TestResolveUnknownDriver(t *testing.T) {
+	info, err := Resolve("unknowndriver")
+	require.Error(t, err)
+	require.Nil(t, info)
+	require.Contains(t, err.Error(), "unknowndriver")
+}
+
+func TestRegisterDialect(t *testing.T) {
+	Register("customdriver", func() IConnectionInfo { return NewPostgresInfo() })
+
+	info, err := Resolve("customdriver")
+	require.NoError(t, err)
+	require.IsType(t, &PostgresInfo{}, info)
+}
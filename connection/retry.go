@@ -0,0 +1,82 @@
+package connection
+
+import (
+	"errors"
+	"reflect"
+)
+
+// sqlStateError error of a driver providing the SQLSTATE code of an error like pgx or lib/pq
+type sqlStateError interface {
+	SQLState() string
+}
+
+// sqlErrorNumberError error of a driver providing the error number of sql server
+type sqlErrorNumberError interface {
+	SQLErrorNumber() int32
+}
+
+// hasSQLState determines whether a driver error provides one of the specified SQLSTATE codes
+func hasSQLState(err error, states ...string) bool {
+	var stateerror sqlStateError
+	if !errors.As(err, &stateerror) {
+		return false
+	}
+
+	for _, state := range states {
+		if stateerror.SQLState() == state {
+			return true
+		}
+	}
+	return false
+}
+
+// hasSQLErrorNumber determines whether a driver error provides one of the specified sql server error numbers
+func hasSQLErrorNumber(err error, numbers ...int32) bool {
+	var numbererror sqlErrorNumberError
+	if !errors.As(err, &numbererror) {
+		return false
+	}
+
+	for _, number := range numbers {
+		if numbererror.SQLErrorNumber() == number {
+			return true
+		}
+	}
+	return false
+}
+
+// hasErrorCode determines whether a driver error provides one of the specified codes in an integer field.
+//              Used for drivers which provide codes as struct fields only like go-sqlite3 (Code) and
+//              go-sql-driver/mysql (Number) without having to depend on the driver.
+func hasErrorCode(err error, field string, codes ...int64) bool {
+	for cause := err; cause != nil; cause = errors.Unwrap(cause) {
+		value := reflect.ValueOf(cause)
+		for value.Kind() == reflect.Ptr && !value.IsNil() {
+			value = value.Elem()
+		}
+
+		if value.Kind() != reflect.Struct {
+			continue
+		}
+
+		codevalue := value.FieldByName(field)
+
+		var code int64
+		switch codevalue.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			code = codevalue.Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			code = int64(codevalue.Uint())
+		default:
+			continue
+		}
+
+		for _, candidate := range codes {
+			if code == candidate {
+				return true
+			}
+		}
+	}
+
+	return false
+}
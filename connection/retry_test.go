@@ -0,0 +1,65 @@
+package connection
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+type stateError struct {
+	state string
+}
+
+func (err *stateError) Error() string {
+	return "pq: could not serialize access due to concurrent update"
+}
+
+func (err *stateError) SQLState() string {
+	return err.state
+}
+
+type numberError struct {
+	number int32
+}
+
+func (err numberError) Error() string {
+	return "mssql: Transaction was deadlocked on lock resources with another process"
+}
+
+func (err numberError) SQLErrorNumber() int32 {
+	return err.number
+}
+
+// shaped like the error of go-sql-driver/mysql
+type mysqlError struct {
+	Number  uint16
+	Message string
+}
+
+func (err *mysqlError) Error() string {
+	return fmt.Sprintf("Error %d: %s", err.Number, err.Message)
+}
+
+func wrap(err error) error {
+	return fmt.Errorf("Error executing 'UPDATE data SET value = ?': %w", err)
+}
+
+func TestIsRetryable(t *testing.T) {
+	require.True(t, NewSqliteInfo().IsRetryable(wrap(sqlite3.Error{Code: sqlite3.ErrBusy})))
+	require.True(t, NewSqliteInfo().IsRetryable(wrap(sqlite3.Error{Code: sqlite3.ErrLocked})))
+	require.True(t, NewPostgresInfo().IsRetryable(wrap(&stateError{state: "40001"})))
+	require.True(t, NewMySQLInfo().IsRetryable(wrap(&mysqlError{Number: 1213, Message: "Deadlock found when trying to get lock"})))
+	require.True(t, NewSQLServerInfo().IsRetryable(wrap(numberError{number: 1205})))
+
+	require.False(t, NewSqliteInfo().IsRetryable(wrap(sqlite3.Error{Code: sqlite3.ErrConstraint})))
+	require.False(t, NewPostgresInfo().IsRetryable(wrap(&stateError{state: "23505"})))
+	require.False(t, NewMySQLInfo().IsRetryable(wrap(&mysqlError{Number: 1062, Message: "Duplicate entry"})))
+	require.False(t, NewSQLServerInfo().IsRetryable(wrap(numberError{number: 2627})))
+
+	// messages are not evaluated
+	require.False(t, NewSqliteInfo().IsRetryable(errors.New("database is locked")))
+	require.False(t, NewPostgresInfo().IsRetryable(nil))
+}
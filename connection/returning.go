@@ -0,0 +1,74 @@
+package connection
+
+import (
+	"strings"
+
+	"github.com/verticalgmbh/database-go/xpr"
+)
+
+// ReturningPosition position in a data modification command at which returned fields can be specified
+type ReturningPosition int
+
+const (
+	// ReturningInline fields are specified within the command, before VALUES of an insert and before WHERE of an update or delete
+	ReturningInline ReturningPosition = iota
+
+	// ReturningTrailing fields are specified at the end of the command
+	ReturningTrailing
+)
+
+// EvaluateReturning evaluation of a trailing RETURNING clause which should work on sqlite and postgres
+//
+// **Parameters**
+//   - position: position in command which is currently written
+//   - fields:   expressions specifying fields to return
+//   - command:  command to write evaluation result to
+//   - eval:     function used to evaluate expressions
+//
+// **Returns**
+//   - error: error if fields could not get evaluated
+func EvaluateReturning(position ReturningPosition, fields []interface{}, command *strings.Builder, eval func(interface{}) error) error {
+	if position != ReturningTrailing {
+		return nil
+	}
+
+	command.WriteString(" RETURNING ")
+	for index, field := range fields {
+		if index > 0 {
+			command.WriteRune(',')
+		}
+
+		err := eval(field)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// evaluateOutput evaluation of an OUTPUT clause returning fields of inserted or deleted rows
+func evaluateOutput(position ReturningPosition, deleted bool, fields []interface{}, command *strings.Builder, eval func(interface{}) error) error {
+	if position != ReturningInline {
+		return nil
+	}
+
+	source := "INSERTED"
+	if deleted {
+		source = "DELETED"
+	}
+
+	command.WriteString(" OUTPUT ")
+	for index, field := range fields {
+		if index > 0 {
+			command.WriteRune(',')
+		}
+
+		err := eval(&xpr.AliasNode{Alias: source, Field: field})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
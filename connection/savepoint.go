@@ -0,0 +1,45 @@
+package connection
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SavepointOperation operation on a savepoint of a transaction
+type SavepointOperation int
+
+const (
+	// SavepointCreate creates a savepoint in a transaction
+	SavepointCreate SavepointOperation = iota
+
+	// SavepointRelease releases a savepoint keeping all changes made after it was created
+	SavepointRelease
+
+	// SavepointRollback rolls back all changes made after the savepoint was created
+	SavepointRollback
+)
+
+// EvaluateSavepoint evaluation of savepoint operations which should work on sqlite, postgres and mysql
+//
+// **Parameters**
+//   - operation: operation to evaluate
+//   - name:      name of savepoint
+//   - command:   command to write evaluation result to
+//
+// **Returns**
+//   - error: error if operation is unknown
+func EvaluateSavepoint(operation SavepointOperation, name string, command *strings.Builder) error {
+	switch operation {
+	case SavepointCreate:
+		command.WriteString("SAVEPOINT ")
+	case SavepointRelease:
+		command.WriteString("RELEASE SAVEPOINT ")
+	case SavepointRollback:
+		command.WriteString("ROLLBACK TO SAVEPOINT ")
+	default:
+		return fmt.Errorf("Invalid savepoint operation %v", operation)
+	}
+
+	command.WriteString(name)
+	return nil
+}
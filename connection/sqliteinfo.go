@@ -1,19 +1,24 @@
 package connection
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"reflect"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/verticalgmbh/database-go/entities/models"
+	"github.com/verticalgmbh/database-go/interfaces"
 	"github.com/verticalgmbh/database-go/xpr"
 )
 
-// SqliteInfo - sqlite specific information
+// SqliteInfo - sqlite specific information. Features are assumed to be supported by the sqlite library bundled
+//              with the driver unless the version of the library is specified using SetVersion or LoadVersion.
 type SqliteInfo struct {
+	noouterjoins bool // RIGHT and FULL OUTER JOIN are only supported since sqlite 3.39.0
 }
 
 // NewSqliteInfo creates a new sqlite info
@@ -24,12 +29,55 @@ func NewSqliteInfo() *SqliteInfo {
 	return &SqliteInfo{}
 }
 
+// SetVersion specifies the version of the sqlite library to determine the supported features
+//
+// **Parameters**
+//   - version: version of sqlite library like '3.38.5'
+//
+// **Returns**
+//   - error: error if version could not get parsed
+func (info *SqliteInfo) SetVersion(version string) error {
+	parts := strings.Split(version, ".")
+	numbers := make([]int, 3)
+	for index := 0; index < len(parts) && index < len(numbers); index++ {
+		number, err := strconv.Atoi(parts[index])
+		if err != nil {
+			return fmt.Errorf("Invalid sqlite version '%s': %w", version, err)
+		}
+		numbers[index] = number
+	}
+
+	info.noouterjoins = numbers[0] < 3 || (numbers[0] == 3 && numbers[1] < 39)
+	return nil
+}
+
+// LoadVersion loads the version of the sqlite library used by a connection to determine the supported features
+//
+// **Parameters**
+//   - connection: connection of which to load sqlite version
+//
+// **Returns**
+//   - error: error if version could not get loaded
+func (info *SqliteInfo) LoadVersion(connection interfaces.IExecutor) error {
+	var version string
+	err := connection.QueryRowContext(context.Background(), "SELECT sqlite_version()").Scan(&version)
+	if err != nil {
+		return fmt.Errorf("Unable to load sqlite version: %w", err)
+	}
+
+	return info.SetVersion(version)
+}
+
 // EvaluateParameter - literal used to specify parameters
 //
 // **Parameters**
-//   - function: function to evaluate
-//   - command: command to write evaluation result to
-func (info *SqliteInfo) EvaluateParameter(parameter *xpr.ParameterNode, command *strings.Builder) {
+//   - function:   function to evaluate
+//   - parameters: parameters already written to the command
+//   - command:    command to write evaluation result to
+//
+// **Returns**
+//   - error: error if parameter can not be represented in database
+func (info *SqliteInfo) EvaluateParameter(parameter *xpr.ParameterNode, parameters *Parameters, command *strings.Builder) error {
 	if len(parameter.Name()) > 0 {
 		// the currently used sqlite package only supports ':' (and not @)
 		command.WriteString(":")
@@ -37,6 +85,7 @@ func (info *SqliteInfo) EvaluateParameter(parameter *xpr.ParameterNode, command
 	} else {
 		command.WriteString("?")
 	}
+	return nil
 }
 
 // MaskColumn masks a column for use in an sql statement
@@ -60,16 +109,81 @@ func (info *SqliteInfo) EvaluateFunction(function *xpr.FunctionNode, command *st
 	return err
 }
 
+// EvaluateOrder evaluates representation of an order criteria in database
+//
+// **Parameters**
+//   - order:   order criteria to evaluate
+//   - command: command to write evaluation result to
+//   - eval:    function used to evaluate expressions
+func (info *SqliteInfo) EvaluateOrder(order *xpr.OrderNode, command *strings.Builder, eval func(interface{}) error) error {
+	return EvaluateOrder(order, command, eval, true)
+}
+
+// EvaluateJoin evaluates representation of a join operator in database
+//
+// **Parameters**
+//   - jointype: type of join to evaluate
+//   - command:  command to write evaluation result to
+//
+// **Returns**
+//   - error: error if database does not support the join type
+func (info *SqliteInfo) EvaluateJoin(jointype xpr.JoinType, command *strings.Builder) error {
+	if info.noouterjoins && (jointype == xpr.JoinRight || jointype == xpr.JoinFull) {
+		return fmt.Errorf("Sqlite versions before 3.39.0 do not support RIGHT and FULL OUTER JOIN")
+	}
+
+	return EvaluateJoin(jointype, command)
+}
+
+// EvaluateWith evaluates representation of the keyword introducing common table expressions
+//
+// **Parameters**
+//   - recursive: determines whether any of the common table expressions is recursive
+//   - command:   command to write evaluation result to
+func (info *SqliteInfo) EvaluateWith(recursive bool, command *strings.Builder) {
+	EvaluateWith(recursive, command)
+}
+
+// EvaluateLimit evaluates representation of a row limit in database
+//
+// **Parameters**
+//   - limit:   expression specifying maximum number of rows to return, nil if number of rows is not limited
+//   - offset:  expression specifying number of rows to skip, nil if no rows are skipped
+//   - ordered: determines whether the command already contains an ORDER BY clause
+//   - command: command to write evaluation result to
+//   - eval:    function used to evaluate expressions
+func (info *SqliteInfo) EvaluateLimit(limit interface{}, offset interface{}, ordered bool, command *strings.Builder, eval func(interface{}) error) error {
+	// sqlite only supports an offset in combination with a limit
+	if limit != nil || offset != nil {
+		command.WriteString(" LIMIT ")
+		if limit != nil {
+			err := eval(limit)
+			if err != nil {
+				return err
+			}
+		} else {
+			command.WriteString("-1")
+		}
+	}
+
+	if offset != nil {
+		command.WriteString(" OFFSET ")
+		return eval(offset)
+	}
+
+	return nil
+}
+
 // ExistsTableOrView determines whether a table exists in database
 //
 // **Parameters**
-//   - connection: connection to database
+//   - connection: connection or transaction used to query the database
 //   - name: name of table or view
 //
 // **Returns**
 //   - bool: true if table or view exists, false otherwise
-func (info *SqliteInfo) ExistsTableOrView(connection *sql.DB, name string) (bool, error) {
-	rows, err := connection.Query("SELECT name FROM sqlite_master WHERE (type='table' OR type='view') AND name = @1", name)
+func (info *SqliteInfo) ExistsTableOrView(connection interfaces.IExecutor, name string) (bool, error) {
+	rows, err := connection.QueryContext(context.Background(), "SELECT name FROM sqlite_master WHERE (type='table' OR type='view') AND name = @1", name)
 	if err != nil {
 		return false, err
 	}
@@ -91,6 +205,8 @@ func (info *SqliteInfo) ExistsTableOrView(connection *sql.DB, name string) (bool
 // **Returns**
 //   - string: database type name
 func (info *SqliteInfo) GetDatabaseType(datatype reflect.Type) string {
+	datatype = models.UnderlyingType(datatype)
+
 	switch datatype.Kind() {
 	case reflect.Bool:
 		return "BOOLEAN"
@@ -147,6 +263,47 @@ func (info *SqliteInfo) CreateColumn(column *models.ColumnDescriptor, command *s
 	}
 }
 
+// AddColumn creates sql text to use when adding a column to an existing table
+//
+// **Parameters**
+//   - table:   name of table to add column to
+//   - column:  column to add
+//   - command: command builder string
+func (info *SqliteInfo) AddColumn(table string, column *models.ColumnDescriptor, command *strings.Builder) {
+	command.WriteString("ALTER TABLE ")
+	command.WriteString(table)
+	command.WriteString(" ADD COLUMN ")
+	info.CreateColumn(column, command)
+}
+
+// DropIndex creates sql text to use when removing an index
+//
+// **Parameters**
+//   - table:    name of table containing index
+//   - name:     name of index to remove
+//   - ifexists: only remove index if it exists
+//   - command:  command builder string
+func (info *SqliteInfo) DropIndex(table string, name string, ifexists bool, command *strings.Builder) {
+	command.WriteString("DROP INDEX ")
+	if ifexists {
+		command.WriteString("IF EXISTS ")
+	}
+	command.WriteString(name)
+}
+
+// RenameTable creates sql text to use when renaming a table
+//
+// **Parameters**
+//   - oldname: current name of table
+//   - newname: name to rename table to
+//   - command: command builder string
+func (info *SqliteInfo) RenameTable(oldname string, newname string, command *strings.Builder) {
+	command.WriteString("ALTER TABLE ")
+	command.WriteString(oldname)
+	command.WriteString(" RENAME TO ")
+	command.WriteString(newname)
+}
+
 func (info *SqliteInfo) analyseColumnDefinition(definition string) (*models.ColumnDescriptor, error) {
 	expression := regexp.MustCompile("^['\\[]?(?P<name>[^ '\\]]+)['\\]]?\\s+(?P<type>[^ ]+)(?P<pk> PRIMARY KEY)?(?P<ai> AUTOINCREMENT)?(?P<uq> UNIQUE)?(?P<nn> NOT NULL)?( DEFAULT '?(?P<default>.+)'?)?$")
 
@@ -222,8 +379,8 @@ func (info *SqliteInfo) analyseTableSQL(sql string) ([]*models.ColumnDescriptor,
 	return tablecolumns, tableuniques, nil
 }
 
-func (info *SqliteInfo) analyseIndexDefinitions(connection *sql.DB, tablename string) ([]*models.IndexDescriptor, error) {
-	indexrows, err := connection.Query("SELECT sql FROM sqlite_master WHERE type='index' AND tbl_name=@1 AND sql IS NOT NULL", tablename)
+func (info *SqliteInfo) analyseIndexDefinitions(connection interfaces.IExecutor, tablename string) ([]*models.IndexDescriptor, error) {
+	indexrows, err := connection.QueryContext(context.Background(), "SELECT sql FROM sqlite_master WHERE type='index' AND tbl_name=@1 AND sql IS NOT NULL", tablename)
 	if err != nil {
 		return nil, err
 	}
@@ -236,7 +393,7 @@ func (info *SqliteInfo) analyseIndexDefinitions(connection *sql.DB, tablename st
 	for indexrows.Next() {
 		err = indexrows.Scan(&indexsql)
 		if err != nil {
-			return nil, fmt.Errorf("Error matching index sql: %s", err.Error())
+			return nil, fmt.Errorf("Error matching index sql: %w", err)
 		}
 
 		if indexsql == "" {
@@ -263,14 +420,14 @@ func (info *SqliteInfo) analyseIndexDefinitions(connection *sql.DB, tablename st
 // GetSchema get schema of a table or view in database
 //
 // **Parameters**
-//   - connection: connection to database
+//   - connection: connection or transaction used to query the database
 //   - name: name of table or view
 //
 // **Returns**
 //   - *Schema: schema information retrieved from database
 //   - error: error information if any error occured
-func (info *SqliteInfo) GetSchema(connection *sql.DB, name string) (models.Schema, error) {
-	row := connection.QueryRow("SELECT type, tbl_name, sql FROM sqlite_master WHERE name=@1", name)
+func (info *SqliteInfo) GetSchema(connection interfaces.IExecutor, name string) (models.Schema, error) {
+	row := connection.QueryRowContext(context.Background(), "SELECT type, tbl_name, sql FROM sqlite_master WHERE name=@1", name)
 
 	var typename string
 	var tablename string
@@ -278,18 +435,18 @@ func (info *SqliteInfo) GetSchema(connection *sql.DB, name string) (models.Schem
 
 	err := row.Scan(&typename, &tablename, &sql)
 	if err != nil {
-		return nil, fmt.Errorf("Error scanning table data: %s", err.Error())
+		return nil, fmt.Errorf("Error scanning table data: %w", err)
 	}
 
 	schema, err := info.toSchema(connection, typename, tablename, sql)
 	if err != nil {
-		return nil, fmt.Errorf("Error converting schema row: %s", err.Error())
+		return nil, fmt.Errorf("Error converting schema row: %w", err)
 	}
 
 	return schema, nil
 }
 
-func (info *SqliteInfo) toSchema(connection *sql.DB, typename string, tablename string, sql string) (models.Schema, error) {
+func (info *SqliteInfo) toSchema(connection interfaces.IExecutor, typename string, tablename string, sql string) (models.Schema, error) {
 	switch typename {
 	case "table":
 		columns, uniques, err := info.analyseTableSQL(sql)
@@ -313,10 +470,10 @@ func (info *SqliteInfo) toSchema(connection *sql.DB, typename string, tablename
 	}
 }
 
-func (info *SqliteInfo) loadSchemas(connection *sql.DB) ([]*SchemaModel, error) {
-	rows, err := connection.Query("SELECT type, tbl_name, sql FROM sqlite_master WHERE type = 'view' OR type = 'table'")
+func (info *SqliteInfo) loadSchemas(connection interfaces.IExecutor) ([]*SchemaModel, error) {
+	rows, err := connection.QueryContext(context.Background(), "SELECT type, tbl_name, sql FROM sqlite_master WHERE type = 'view' OR type = 'table'")
 	if err != nil {
-		return nil, fmt.Errorf("Unable to retrieve schema infos: %s", err.Error())
+		return nil, fmt.Errorf("Unable to retrieve schema infos: %w", err)
 	}
 
 	defer rows.Close()
@@ -329,7 +486,7 @@ func (info *SqliteInfo) loadSchemas(connection *sql.DB) ([]*SchemaModel, error)
 
 		err := rows.Scan(&typename, &tablename, &sql)
 		if err != nil {
-			return nil, fmt.Errorf("Error scanning table data: %s", err.Error())
+			return nil, fmt.Errorf("Error scanning table data: %w", err)
 		}
 
 		if !sql.Valid || strings.HasPrefix(tablename, "sqlite") {
@@ -353,10 +510,10 @@ func (info *SqliteInfo) loadSchemas(connection *sql.DB) ([]*SchemaModel, error)
 // **Returns**
 //   - []Schema: schemas in database
 //   - error   : errors if any occured
-func (info *SqliteInfo) GetSchemas(connection *sql.DB) ([]models.Schema, error) {
+func (info *SqliteInfo) GetSchemas(connection interfaces.IExecutor) ([]models.Schema, error) {
 	schemas, err := info.loadSchemas(connection)
 	if err != nil {
-		return nil, fmt.Errorf("Unable to load schema information: %s", err.Error())
+		return nil, fmt.Errorf("Unable to load schema information: %w", err)
 	}
 
 	var result []models.Schema
@@ -364,7 +521,7 @@ func (info *SqliteInfo) GetSchemas(connection *sql.DB) ([]models.Schema, error)
 	for _, schemainfo := range schemas {
 		schema, err := info.toSchema(connection, schemainfo.SchemaType, schemainfo.TableName, schemainfo.SQL)
 		if err != nil {
-			return nil, fmt.Errorf("Error creating schema: %s", err.Error())
+			return nil, fmt.Errorf("Error creating schema: %w", err)
 		}
 
 		result = append(result, schema)
@@ -373,10 +530,81 @@ func (info *SqliteInfo) GetSchemas(connection *sql.DB) ([]models.Schema, error)
 	return result, nil
 }
 
+// EvaluateUpsert evaluates representation of an insert operation which updates existing rows on conflict
+//
+// **Parameters**
+//   - upsert:  upsert operation to evaluate
+//   - command: command to write evaluation result to
+//
+// **Returns**
+//   - error: error if upsert can not be represented in database
+func (info *SqliteInfo) EvaluateUpsert(upsert *Upsert, command *strings.Builder) error {
+	return EvaluateOnConflict(upsert, info, command)
+}
+
+// EvaluateReturning evaluates representation of fields returned by a data modification command
+//
+// **Parameters**
+//   - position: position in command which is currently written
+//   - deleted:  determines whether command deletes rows, so fields of deleted rows are returned
+//   - fields:   expressions specifying fields to return
+//   - command:  command to write evaluation result to
+//   - eval:     function used to evaluate expressions
+//
+// **Returns**
+//   - error: error if database does not support to return fields
+func (info *SqliteInfo) EvaluateReturning(position ReturningPosition, deleted bool, fields []interface{}, command *strings.Builder, eval func(interface{}) error) error {
+	// RETURNING is supported since sqlite 3.35
+	return EvaluateReturning(position, fields, command, eval)
+}
+
+// EvaluateSavepoint evaluates representation of an operation on a savepoint of a transaction
+//
+// **Parameters**
+//   - operation: operation to evaluate
+//   - name:      name of savepoint
+//   - command:   command to write evaluation result to
+//
+// **Returns**
+//   - error: error if database does not support the operation
+func (info *SqliteInfo) EvaluateSavepoint(operation SavepointOperation, name string, command *strings.Builder) error {
+	return EvaluateSavepoint(operation, name, command)
+}
+
+// IsRetryable determines whether an error is caused by a conflict with a concurrent transaction
+//
+// **Parameters**
+//   - err: error to evaluate
+//
+// **Returns**
+//   - bool: true if operation which caused the error can get retried, false otherwise
+func (info *SqliteInfo) IsRetryable(err error) bool {
+	// SQLITE_BUSY and SQLITE_LOCKED
+	return hasErrorCode(err, "Code", 5, 6)
+}
+
+// MaxBatchRows maximum number of rows which can be inserted using a single statement
+//
+// **Parameters**
+//   - columns: number of columns filled per row
+//
+// **Returns**
+//   - int: maximum number of rows per statement, 0 if a single row exceeds the number of supported parameters
+func (info *SqliteInfo) MaxBatchRows(columns int) int {
+	// sqlite is compiled with a limit of 999 parameters by default up to version 3.32
+	return MaxBatchRows(columns, 999)
+}
+
 // ReturnIdentity adds a statement to command which returns identity of last inserted row
 //
 // **Parameters**
-//   - command: statement to modify
-func (info *SqliteInfo) ReturnIdentity(command *strings.Builder) string {
-	return "SELECT last_insert_rowid()"
+//   - identity: column containing the identity of the row
+//   - command:  statement to modify
+//
+// **Returns**
+//   - bool:  true if the command returns the identity as result row, false if the identity is provided by the execution result
+//   - error: error if the identity can not get returned by the database
+func (info *SqliteInfo) ReturnIdentity(identity *models.ColumnDescriptor, command *strings.Builder) (bool, error) {
+	// the driver provides the rowid of the inserted row as last insert id of the execution result
+	return false, nil
 }
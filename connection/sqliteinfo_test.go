@@ -2,9 +2,14 @@ package connection
 
 import (
 	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/verticalgmbh/database-go/entities/models"
+	"github.com/verticalgmbh/database-go/xpr"
 
 	_ "github.com/mattn/go-sqlite3"
 	"github.com/stretchr/testify/assert"
@@ -62,3 +67,71 @@ func TestGetSchema(t *testing.T) {
 
 	assert.Equal(t, 0, len(expectedcolumns))
 }
+
+// uuid type stored as text like the uuid types of common uuid packages
+type testUUID [16]byte
+
+func (value testUUID) Value() (driver.Value, error) {
+	return "00000000-0000-0000-0000-000000000000", nil
+}
+
+func (value *testUUID) Scan(source interface{}) error {
+	return errors.New("scanning is not supported")
+}
+
+type testNullUUID struct {
+	UUID  testUUID
+	Valid bool
+}
+
+func (value *testNullUUID) Scan(source interface{}) error {
+	return value.UUID.Scan(source)
+}
+
+// struct implementing sql.Scanner which does not represent a nullable value
+type testPoint struct {
+	X float64
+	Y float64
+}
+
+func (value *testPoint) Scan(source interface{}) error {
+	return errors.New("scanning is not supported")
+}
+
+func TestNullableDatabaseTypes(t *testing.T) {
+	connectioninfo := SqliteInfo{}
+
+	assert.Equal(t, "INTEGER", connectioninfo.GetDatabaseType(reflect.TypeOf((*int64)(nil))))
+	assert.Equal(t, "INTEGER", connectioninfo.GetDatabaseType(reflect.TypeOf(sql.NullInt64{})))
+	assert.Equal(t, "TEXT", connectioninfo.GetDatabaseType(reflect.TypeOf(sql.NullString{})))
+	assert.Equal(t, "TIMESTAMP", connectioninfo.GetDatabaseType(reflect.TypeOf(sql.NullTime{})))
+	assert.Equal(t, "FLOAT", connectioninfo.GetDatabaseType(reflect.TypeOf(sql.NullFloat64{})))
+	assert.Equal(t, "TEXT", connectioninfo.GetDatabaseType(reflect.TypeOf(testUUID{})))
+	assert.Equal(t, "TEXT", connectioninfo.GetDatabaseType(reflect.TypeOf(testNullUUID{})))
+	assert.Equal(t, "TEXT", connectioninfo.GetDatabaseType(reflect.TypeOf(&testPoint{})))
+	assert.Equal(t, "BLOB", connectioninfo.GetDatabaseType(reflect.TypeOf([16]byte{})))
+}
+
+func TestSqliteOuterJoinVersion(t *testing.T) {
+	database, err := sql.Open("sqlite3", ":memory:")
+	assert.NoError(t, err)
+
+	defer database.Close()
+
+	var command strings.Builder
+	connectioninfo := NewSqliteInfo()
+
+	assert.NoError(t, connectioninfo.LoadVersion(database))
+	assert.NoError(t, connectioninfo.EvaluateJoin(xpr.JoinRight, &command))
+	assert.NoError(t, connectioninfo.EvaluateJoin(xpr.JoinFull, &command))
+
+	assert.NoError(t, connectioninfo.SetVersion("3.38.5"))
+	assert.NoError(t, connectioninfo.EvaluateJoin(xpr.JoinLeft, &command))
+	assert.Error(t, connectioninfo.EvaluateJoin(xpr.JoinRight, &command))
+	assert.Error(t, connectioninfo.EvaluateJoin(xpr.JoinFull, &command))
+
+	assert.NoError(t, connectioninfo.SetVersion("3.39.0"))
+	assert.NoError(t, connectioninfo.EvaluateJoin(xpr.JoinFull, &command))
+
+	assert.Error(t, connectioninfo.SetVersion("latest"))
+}
@@ -1,32 +1,49 @@
 package connection
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
-	"log"
 	"reflect"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/verticalgmbh/database-go/entities/models"
+	"github.com/verticalgmbh/database-go/interfaces"
 	"github.com/verticalgmbh/database-go/xpr"
 )
 
-// SQLServerInfo - ms sql server specific information
+// SQLServerInfo - ms sql server specific information. Commands use ordinal parameters (@p1, @p2, ...) which
+//                 are supported by go-mssqldb when registered as "sqlserver" driver.
 type SQLServerInfo struct {
 }
 
+// NewSQLServerInfo creates a new sql server info
+//
+// **Returns**
+//   - *SQLServerInfo: created sql server connection info
+func NewSQLServerInfo() *SQLServerInfo {
+	return &SQLServerInfo{}
+}
+
 // EvaluateParameter - literal used to specify parameters
 //
+// parameters are written as ordinal parameters @p1, @p2, ... as expected by the sqlserver driver of go-mssqldb.
+// Named parameters are written as is if their name is an index, all other parameters get the next index following
+// the highest index already assigned. Named parameters used multiple times get the same index every time.
+//
 // **Parameters**
-//   - function: function to evaluate
-//   - command: command to write evaluation result to
-func (info *SQLServerInfo) EvaluateParameter(parameter *xpr.ParameterNode, command *strings.Builder) {
-	if len(parameter.Name()) > 0 {
-		command.WriteString("@")
-		command.WriteString(parameter.Name())
-	} else {
-		command.WriteString("?")
-	}
+//   - function:   function to evaluate
+//   - parameters: parameters already written to the command
+//   - command:    command to write evaluation result to
+//
+// **Returns**
+//   - error: error if parameter can not be represented in database
+func (info *SQLServerInfo) EvaluateParameter(parameter *xpr.ParameterNode, parameters *Parameters, command *strings.Builder) error {
+	command.WriteString("@p")
+	command.WriteString(strconv.Itoa(parameters.Index(parameter.Name())))
+	return nil
 }
 
 // MaskColumn masks a column for use in an sql statement
@@ -50,16 +67,99 @@ func (info *SQLServerInfo) EvaluateFunction(function *xpr.FunctionNode, command
 	return err
 }
 
+// EvaluateOrder evaluates representation of an order criteria in database
+//
+// **Parameters**
+//   - order:   order criteria to evaluate
+//   - command: command to write evaluation result to
+//   - eval:    function used to evaluate expressions
+func (info *SQLServerInfo) EvaluateOrder(order *xpr.OrderNode, command *strings.Builder, eval func(interface{}) error) error {
+	return EvaluateOrder(order, command, eval, false)
+}
+
+// EvaluateJoin evaluates representation of a join operator in database
+//
+// **Parameters**
+//   - jointype: type of join to evaluate
+//   - command:  command to write evaluation result to
+//
+// **Returns**
+//   - error: error if database does not support the join type
+func (info *SQLServerInfo) EvaluateJoin(jointype xpr.JoinType, command *strings.Builder) error {
+	return EvaluateJoin(jointype, command)
+}
+
+// EvaluateWith evaluates representation of the keyword introducing common table expressions
+//
+// **Parameters**
+//   - recursive: determines whether any of the common table expressions is recursive
+//   - command:   command to write evaluation result to
+func (info *SQLServerInfo) EvaluateWith(recursive bool, command *strings.Builder) {
+	// sql server detects recursion by itself and does not know the RECURSIVE keyword
+	command.WriteString("WITH ")
+}
+
+// EvaluateLimit evaluates representation of a row limit in database
+//
+// **Parameters**
+//   - limit:   expression specifying maximum number of rows to return, nil if number of rows is not limited
+//   - offset:  expression specifying number of rows to skip, nil if no rows are skipped
+//   - ordered: determines whether the command already contains an ORDER BY clause
+//   - command: command to write evaluation result to
+//   - eval:    function used to evaluate expressions
+func (info *SQLServerInfo) EvaluateLimit(limit interface{}, offset interface{}, ordered bool, command *strings.Builder, eval func(interface{}) error) error {
+	if limit == nil && offset == nil {
+		return nil
+	}
+
+	// OFFSET ... FETCH is only valid with an ORDER BY clause
+	if !ordered {
+		command.WriteString(" ORDER BY (SELECT NULL)")
+	}
+
+	command.WriteString(" OFFSET ")
+	if offset != nil {
+		err := eval(offset)
+		if err != nil {
+			return err
+		}
+	} else {
+		command.WriteRune('0')
+	}
+	command.WriteString(" ROWS")
+
+	if limit != nil {
+		command.WriteString(" FETCH NEXT ")
+		err := eval(limit)
+		if err != nil {
+			return err
+		}
+		command.WriteString(" ROWS ONLY")
+	}
+
+	return nil
+}
+
 // ExistsTableOrView determines whether a table exists in database
 //
 // **Parameters**
-//   - connection: connection to database
+//   - connection: connection or transaction used to query the database
 //   - name: name of table or view
 //
 // **Returns**
 //   - bool: true if table or view exists, false otherwise
-func (info *SQLServerInfo) ExistsTableOrView(connection *sql.DB, name string) (bool, error) {
-	log.Panicf("Not implemented")
+func (info *SQLServerInfo) ExistsTableOrView(connection interfaces.IExecutor, name string) (bool, error) {
+	rows, err := connection.QueryContext(context.Background(), "SELECT name FROM sys.objects WHERE type IN ('U','V') AND name = @p1", name)
+	if err != nil {
+		return false, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		return true, nil
+	}
+
 	return false, nil
 }
 
@@ -71,8 +171,33 @@ func (info *SQLServerInfo) ExistsTableOrView(connection *sql.DB, name string) (b
 // **Returns**
 //   - string: database type name
 func (info *SQLServerInfo) GetDatabaseType(datatype reflect.Type) string {
-	log.Panicf("Not implemented")
-	return ""
+	datatype = models.UnderlyingType(datatype)
+
+	switch datatype.Kind() {
+	case reflect.Bool:
+		return "BIT"
+	case reflect.String:
+		return "NVARCHAR(MAX)"
+	case reflect.Int8, reflect.Int16, reflect.Uint8:
+		return "SMALLINT"
+	case reflect.Int32, reflect.Uint16:
+		return "INT"
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return "BIGINT"
+	case reflect.Float32:
+		return "REAL"
+	case reflect.Float64:
+		return "FLOAT"
+	case reflect.Slice, reflect.Array:
+		return "VARBINARY(MAX)"
+	case reflect.Struct:
+		if datatype == reflect.TypeOf(time.Time{}) {
+			return "DATETIME2"
+		}
+		return "NVARCHAR(MAX)"
+	default:
+		return "NVARCHAR(MAX)"
+	}
 }
 
 // CreateColumn creates sql text to use when creating a column
@@ -81,21 +206,294 @@ func (info *SQLServerInfo) GetDatabaseType(datatype reflect.Type) string {
 //   - column:  column to create
 //   - command: command builder string
 func (info *SQLServerInfo) CreateColumn(column *models.ColumnDescriptor, command *strings.Builder) {
-	log.Panicf("Not implemented")
+	command.WriteString(info.MaskColumn(column.Name()))
+
+	datatype := info.GetDatabaseType(column.DataType())
+	if datatype == "NVARCHAR(MAX)" && (column.IsPrimaryKey() || column.IsUnique()) {
+		// key columns are limited to 900 bytes
+		datatype = "NVARCHAR(450)"
+	}
+	command.WriteString(fmt.Sprintf(" %s", datatype))
+
+	if column.IsAutoIncrement() {
+		command.WriteRune(' ')
+		command.WriteString("IDENTITY(1,1)")
+	}
+
+	if column.IsPrimaryKey() {
+		command.WriteRune(' ')
+		command.WriteString("PRIMARY KEY")
+	}
+
+	if column.IsUnique() {
+		command.WriteRune(' ')
+		command.WriteString("UNIQUE")
+	}
+
+	if column.IsNotNull() {
+		command.WriteRune(' ')
+		command.WriteString("NOT NULL")
+	}
+
+	if column.DefaultValue() != "" {
+		command.WriteString(" DEFAULT ")
+		command.WriteString(info.toDefaultLiteral(column))
+	}
+}
+
+// AddColumn creates sql text to use when adding a column to an existing table
+//
+// **Parameters**
+//   - table:   name of table to add column to
+//   - column:  column to add
+//   - command: command builder string
+func (info *SQLServerInfo) AddColumn(table string, column *models.ColumnDescriptor, command *strings.Builder) {
+	command.WriteString("ALTER TABLE ")
+	command.WriteString(table)
+	command.WriteString(" ADD ")
+	info.CreateColumn(column, command)
+}
+
+// DropIndex creates sql text to use when removing an index
+//
+// **Parameters**
+//   - table:    name of table containing index
+//   - name:     name of index to remove
+//   - ifexists: only remove index if it exists
+//   - command:  command builder string
+func (info *SQLServerInfo) DropIndex(table string, name string, ifexists bool, command *strings.Builder) {
+	command.WriteString("DROP INDEX ")
+	if ifexists {
+		command.WriteString("IF EXISTS ")
+	}
+	command.WriteString(name)
+	command.WriteString(" ON ")
+	command.WriteString(table)
+}
+
+// RenameTable creates sql text to use when renaming a table
+//
+// **Parameters**
+//   - oldname: current name of table
+//   - newname: name to rename table to
+//   - command: command builder string
+func (info *SQLServerInfo) RenameTable(oldname string, newname string, command *strings.Builder) {
+	command.WriteString("EXEC sp_rename '")
+	command.WriteString(oldname)
+	command.WriteString("', '")
+	command.WriteString(newname)
+	command.WriteString("'")
+}
+
+func (info *SQLServerInfo) toDatabaseType(datatype string, maxlength int) string {
+	datatype = strings.ToUpper(datatype)
+	switch datatype {
+	case "NVARCHAR", "NCHAR", "VARCHAR", "CHAR", "VARBINARY", "BINARY":
+		if maxlength < 0 {
+			return fmt.Sprintf("%s(MAX)", datatype)
+		}
+
+		// max_length is specified in bytes
+		if datatype == "NVARCHAR" || datatype == "NCHAR" {
+			maxlength /= 2
+		}
+		return fmt.Sprintf("%s(%d)", datatype, maxlength)
+	default:
+		return datatype
+	}
+}
+
+func (info *SQLServerInfo) toDefaultLiteral(column *models.ColumnDescriptor) string {
+	// sql server has no boolean literals so boolean defaults are written as bit values
+	if models.UnderlyingType(column.DataType()).Kind() == reflect.Bool {
+		switch strings.ToLower(column.DefaultValue()) {
+		case "true":
+			return "1"
+		case "false":
+			return "0"
+		}
+	}
+
+	return column.DefaultValue()
+}
+
+func (info *SQLServerInfo) toDefaultValue(definition string) string {
+	// sql server stores default values wrapped in parentheses
+	for strings.HasPrefix(definition, "(") && strings.HasSuffix(definition, ")") {
+		definition = definition[1 : len(definition)-1]
+	}
+	return definition
+}
+
+func (info *SQLServerInfo) analyseIndices(connection interfaces.IExecutor, tablename string) (map[string]bool, map[string]bool, []*models.IndexDescriptor, []*models.IndexDescriptor, error) {
+	rows, err := connection.QueryContext(context.Background(), "SELECT i.name, i.is_primary_key, i.is_unique_constraint, c.name FROM sys.indexes i INNER JOIN sys.index_columns ic ON ic.object_id = i.object_id AND ic.index_id = i.index_id INNER JOIN sys.columns c ON c.object_id = ic.object_id AND c.column_id = ic.column_id WHERE i.object_id = OBJECT_ID(@p1) ORDER BY i.name, ic.key_ordinal", tablename)
+	if err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("Unable to load indices: %w", err)
+	}
+	defer rows.Close()
+
+	primarykeys := make(map[string]bool)
+	var uniquenames []string
+	uniquecolumns := make(map[string][]string)
+	var indexnames []string
+	indexcolumns := make(map[string][]string)
+	for rows.Next() {
+		var name string
+		var isprimarykey bool
+		var isunique bool
+		var column string
+
+		err = rows.Scan(&name, &isprimarykey, &isunique, &column)
+		if err != nil {
+			return nil, nil, nil, nil, fmt.Errorf("Error scanning index data: %w", err)
+		}
+
+		switch {
+		case isprimarykey:
+			primarykeys[column] = true
+		case isunique:
+			if _, exists := uniquecolumns[name]; !exists {
+				uniquenames = append(uniquenames, name)
+			}
+			uniquecolumns[name] = append(uniquecolumns[name], column)
+		default:
+			if _, exists := indexcolumns[name]; !exists {
+				indexnames = append(indexnames, name)
+			}
+			indexcolumns[name] = append(indexcolumns[name], column)
+		}
+	}
+
+	unique := make(map[string]bool)
+	var uniques []*models.IndexDescriptor
+	for _, name := range uniquenames {
+		columns := uniquecolumns[name]
+		if len(columns) == 1 {
+			unique[columns[0]] = true
+		} else {
+			uniques = append(uniques, models.NewIndexDescriptor("", columns...))
+		}
+	}
+
+	prefix := fmt.Sprintf("idx_%s_", tablename)
+	var indices []*models.IndexDescriptor
+	for _, name := range indexnames {
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+
+		indices = append(indices, models.NewIndexDescriptor(name[len(prefix):], indexcolumns[name]...))
+	}
+
+	return primarykeys, unique, indices, uniques, nil
+}
+
+func (info *SQLServerInfo) analyseTable(connection interfaces.IExecutor, tablename string) (*models.Table, error) {
+	primarykeys, unique, indices, uniques, err := info.analyseIndices(connection, tablename)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := connection.QueryContext(context.Background(), "SELECT c.name, t.name, c.max_length, c.is_nullable, c.is_identity, d.definition FROM sys.columns c INNER JOIN sys.types t ON t.user_type_id = c.user_type_id LEFT JOIN sys.default_constraints d ON d.object_id = c.default_object_id WHERE c.object_id = OBJECT_ID(@p1) ORDER BY c.column_id", tablename)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to load columns: %w", err)
+	}
+	defer rows.Close()
+
+	var columns []*models.ColumnDescriptor
+	for rows.Next() {
+		var name string
+		var datatype string
+		var maxlength int
+		var isnullable bool
+		var isidentity bool
+		var defaultvalue sql.NullString
+
+		err = rows.Scan(&name, &datatype, &maxlength, &isnullable, &isidentity, &defaultvalue)
+		if err != nil {
+			return nil, fmt.Errorf("Error scanning column data: %w", err)
+		}
+
+		// primary keys are implicitly not null
+		isnotnull := !isnullable && !primarykeys[name]
+
+		columns = append(columns, models.NewSchemaColumn(name, info.toDatabaseType(datatype, maxlength), primarykeys[name], isidentity, unique[name], isnotnull, info.toDefaultValue(defaultvalue.String)))
+	}
+
+	return models.NewTableDescriptor(tablename, columns, indices, uniques), nil
 }
 
 // GetSchema get schema of a table or view in database
 //
 // **Parameters**
-//   - connection: connection to database
+//   - connection: connection or transaction used to query the database
 //   - name: name of table or view
 //
 // **Returns**
 //   - *Schema: schema information retrieved from database
 //   - error: error information if any error occured
-func (info *SQLServerInfo) GetSchema(connection *sql.DB, name string) (models.Schema, error) {
-	log.Panicf("Not implemented")
-	return nil, nil
+func (info *SQLServerInfo) GetSchema(connection interfaces.IExecutor, name string) (models.Schema, error) {
+	row := connection.QueryRowContext(context.Background(), "SELECT type FROM sys.objects WHERE type IN ('U','V') AND name = @p1", name)
+
+	var typename string
+	err := row.Scan(&typename)
+	if err != nil {
+		return nil, fmt.Errorf("Error scanning table data: %w", err)
+	}
+
+	schema, err := info.toSchema(connection, typename, name)
+	if err != nil {
+		return nil, fmt.Errorf("Error converting schema row: %w", err)
+	}
+
+	return schema, nil
+}
+
+func (info *SQLServerInfo) toSchema(connection interfaces.IExecutor, typename string, tablename string) (models.Schema, error) {
+	switch strings.TrimSpace(typename) {
+	case "U":
+		return info.analyseTable(connection, tablename)
+	case "V":
+		row := connection.QueryRowContext(context.Background(), "SELECT OBJECT_DEFINITION(OBJECT_ID(@p1))", tablename)
+
+		var sql string
+		err := row.Scan(&sql)
+		if err != nil {
+			return nil, fmt.Errorf("Error scanning view data: %w", err)
+		}
+
+		return &models.View{
+			Name: tablename,
+			SQL:  sql}, nil
+	default:
+		return nil, fmt.Errorf("Unsupported table type '%s'", typename)
+	}
+}
+
+func (info *SQLServerInfo) loadSchemas(connection interfaces.IExecutor) ([]*SchemaModel, error) {
+	rows, err := connection.QueryContext(context.Background(), "SELECT type, name FROM sys.objects WHERE type IN ('U','V') AND is_ms_shipped = 0")
+	if err != nil {
+		return nil, fmt.Errorf("Unable to retrieve schema infos: %w", err)
+	}
+
+	defer rows.Close()
+
+	var schemas []*SchemaModel
+	for rows.Next() {
+		var typename string
+		var tablename string
+
+		err := rows.Scan(&typename, &tablename)
+		if err != nil {
+			return nil, fmt.Errorf("Error scanning table data: %w", err)
+		}
+
+		schemas = append(schemas, &SchemaModel{
+			SchemaType: typename,
+			TableName:  tablename})
+	}
+
+	return schemas, nil
 }
 
 // GetSchemas get all schemas in database
@@ -106,16 +504,170 @@ func (info *SQLServerInfo) GetSchema(connection *sql.DB, name string) (models.Sc
 // **Returns**
 //   - []Schema: schemas in database
 //   - error   : errors if any occured
-func (info *SQLServerInfo) GetSchemas(connection *sql.DB) ([]models.Schema, error) {
-	log.Panicf("Not implemented")
-	return nil, nil
+func (info *SQLServerInfo) GetSchemas(connection interfaces.IExecutor) ([]models.Schema, error) {
+	schemas, err := info.loadSchemas(connection)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to load schema information: %w", err)
+	}
+
+	var result []models.Schema
+
+	for _, schemainfo := range schemas {
+		schema, err := info.toSchema(connection, schemainfo.SchemaType, schemainfo.TableName)
+		if err != nil {
+			return nil, fmt.Errorf("Error creating schema: %w", err)
+		}
+
+		result = append(result, schema)
+	}
+
+	return result, nil
+}
+
+// EvaluateUpsert evaluates representation of an insert operation which updates existing rows on conflict
+//
+// **Parameters**
+//   - upsert:  upsert operation to evaluate
+//   - command: command to write evaluation result to
+//
+// **Returns**
+//   - error: error if upsert can not be represented in database
+func (info *SQLServerInfo) EvaluateUpsert(upsert *Upsert, command *strings.Builder) error {
+	if len(upsert.Conflict) == 0 {
+		return fmt.Errorf("Upsert needs at least one conflict column")
+	}
+
+	command.WriteString("MERGE INTO ")
+	command.WriteString(upsert.Table)
+	command.WriteString(" WITH (HOLDLOCK) AS target USING (VALUES(")
+	parameters := NewParameters()
+	for index := range upsert.Columns {
+		if index > 0 {
+			command.WriteRune(',')
+		}
+		err := info.EvaluateParameter(xpr.Parameter(), parameters, command)
+		if err != nil {
+			return err
+		}
+	}
+	command.WriteString(")) AS source (")
+	writeColumnList(upsert.Columns, "", info, command)
+	command.WriteString(") ON ")
+
+	for index, column := range upsert.Conflict {
+		if index > 0 {
+			command.WriteString(" AND ")
+		}
+
+		command.WriteString("target.")
+		command.WriteString(info.MaskColumn(column))
+		command.WriteString(" = source.")
+		command.WriteString(info.MaskColumn(column))
+	}
+
+	if len(upsert.Update) > 0 {
+		command.WriteString(" WHEN MATCHED THEN UPDATE SET ")
+		for index, column := range upsert.Update {
+			if index > 0 {
+				command.WriteRune(',')
+			}
+
+			command.WriteString(info.MaskColumn(column))
+			command.WriteString(" = source.")
+			command.WriteString(info.MaskColumn(column))
+		}
+	}
+
+	command.WriteString(" WHEN NOT MATCHED THEN INSERT (")
+	writeColumnList(upsert.Columns, "", info, command)
+	command.WriteString(") VALUES(")
+	writeColumnList(upsert.Columns, "source.", info, command)
+
+	// merge statements have to be terminated by a semicolon
+	command.WriteString(");")
+	return nil
+}
+
+// EvaluateReturning evaluates representation of fields returned by a data modification command
+//
+// **Parameters**
+//   - position: position in command which is currently written
+//   - deleted:  determines whether command deletes rows, so fields of deleted rows are returned
+//   - fields:   expressions specifying fields to return
+//   - command:  command to write evaluation result to
+//   - eval:     function used to evaluate expressions
+//
+// **Returns**
+//   - error: error if database does not support to return fields
+func (info *SQLServerInfo) EvaluateReturning(position ReturningPosition, deleted bool, fields []interface{}, command *strings.Builder, eval func(interface{}) error) error {
+	return evaluateOutput(position, deleted, fields, command, eval)
+}
+
+// EvaluateSavepoint evaluates representation of an operation on a savepoint of a transaction
+//
+// **Parameters**
+//   - operation: operation to evaluate
+//   - name:      name of savepoint
+//   - command:   command to write evaluation result to
+//
+// **Returns**
+//   - error: error if database does not support the operation
+func (info *SQLServerInfo) EvaluateSavepoint(operation SavepointOperation, name string, command *strings.Builder) error {
+	switch operation {
+	case SavepointCreate:
+		command.WriteString("SAVE TRANSACTION ")
+	case SavepointRelease:
+		// savepoints of sql server can not get released, they are discarded when the transaction ends
+		return nil
+	case SavepointRollback:
+		command.WriteString("ROLLBACK TRANSACTION ")
+	default:
+		return fmt.Errorf("Invalid savepoint operation %v", operation)
+	}
+
+	command.WriteString(name)
+	return nil
+}
+
+// IsRetryable determines whether an error is caused by a conflict with a concurrent transaction
+//
+// **Parameters**
+//   - err: error to evaluate
+//
+// **Returns**
+//   - bool: true if operation which caused the error can get retried, false otherwise
+func (info *SQLServerInfo) IsRetryable(err error) bool {
+	// deadlock victim and snapshot isolation update conflict
+	return hasSQLErrorNumber(err, 1205, 3960)
+}
+
+// MaxBatchRows maximum number of rows which can be inserted using a single statement
+//
+// **Parameters**
+//   - columns: number of columns filled per row
+//
+// **Returns**
+//   - int: maximum number of rows per statement, 0 if a single row exceeds the number of supported parameters
+func (info *SQLServerInfo) MaxBatchRows(columns int) int {
+	// statements are sent using sp_executesql which takes two of the 2100 supported parameters itself.
+	// a table value constructor is limited to 1000 rows besides the parameter limit
+	rows := MaxBatchRows(columns, 2098)
+	if rows > 1000 {
+		return 1000
+	}
+	return rows
 }
 
 // ReturnIdentity adds a statement to command which returns identity of last inserted row
 //
 // **Parameters**
-//   - command: statement to modify
-func (info *SQLServerInfo) ReturnIdentity(command *strings.Builder) string {
-	command.WriteString(";SELECT SCOPE_IDENTITY()")
-	return ""
+//   - identity: column containing the identity of the row
+//   - command:  statement to modify
+//
+// **Returns**
+//   - bool:  true if the command returns the identity as result row, false if the identity is provided by the execution result
+//   - error: error if the identity can not get returned by the database
+func (info *SQLServerInfo) ReturnIdentity(identity *models.ColumnDescriptor, command *strings.Builder) (bool, error) {
+	command.WriteString(";SELECT CAST(SCOPE_IDENTITY() AS BIGINT)")
+	return true, nil
 }
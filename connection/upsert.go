@@ -0,0 +1,84 @@
+package connection
+
+import (
+	"strings"
+
+	"github.com/verticalgmbh/database-go/xpr"
+)
+
+// Upsert description of an insert operation which updates existing rows on conflict
+type Upsert struct {
+	Table    string   // table to insert rows into
+	Columns  []string // columns to fill with parameters
+	Conflict []string // columns identifying conflicting rows
+	Update   []string // columns to update with the inserted value on conflict, nothing is updated if empty
+}
+
+// writeUpsertInsert writes the insert part of an upsert command
+func writeUpsertInsert(upsert *Upsert, info IConnectionInfo, command *strings.Builder) error {
+	command.WriteString("INSERT INTO ")
+	command.WriteString(upsert.Table)
+	command.WriteString(" (")
+	writeColumnList(upsert.Columns, "", info, command)
+	command.WriteString(") VALUES(")
+	parameters := NewParameters()
+	for index := range upsert.Columns {
+		if index > 0 {
+			command.WriteRune(',')
+		}
+		err := info.EvaluateParameter(xpr.Parameter(), parameters, command)
+		if err != nil {
+			return err
+		}
+	}
+	command.WriteRune(')')
+	return nil
+}
+
+// writeColumnList writes a comma separated list of masked columns
+func writeColumnList(columns []string, prefix string, info IConnectionInfo, command *strings.Builder) {
+	for index, column := range columns {
+		if index > 0 {
+			command.WriteRune(',')
+		}
+		command.WriteString(prefix)
+		command.WriteString(info.MaskColumn(column))
+	}
+}
+
+// EvaluateOnConflict upsert evaluation for databases supporting ON CONFLICT clauses like sqlite and postgres
+//
+// **Parameters**
+//   - upsert:  upsert operation to evaluate
+//   - info:    driver specific information used to mask columns and evaluate parameters
+//   - command: command to write evaluation result to
+//
+// **Returns**
+//   - error: error if upsert can not be represented in database
+func EvaluateOnConflict(upsert *Upsert, info IConnectionInfo, command *strings.Builder) error {
+	err := writeUpsertInsert(upsert, info, command)
+	if err != nil {
+		return err
+	}
+
+	command.WriteString(" ON CONFLICT(")
+	writeColumnList(upsert.Conflict, "", info, command)
+	command.WriteString(") DO ")
+
+	if len(upsert.Update) == 0 {
+		command.WriteString("NOTHING")
+		return nil
+	}
+
+	command.WriteString("UPDATE SET ")
+	for index, column := range upsert.Update {
+		if index > 0 {
+			command.WriteRune(',')
+		}
+
+		command.WriteString(info.MaskColumn(column))
+		command.WriteString(" = excluded.")
+		command.WriteString(info.MaskColumn(column))
+	}
+	return nil
+}
@@ -5,10 +5,13 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"reflect"
 
 	"github.com/verticalgmbh/database-go/connection"
 	"github.com/verticalgmbh/database-go/entities/models"
 	"github.com/verticalgmbh/database-go/entities/statements"
+	"github.com/verticalgmbh/database-go/interfaces"
+	"github.com/verticalgmbh/database-go/xpr"
 )
 
 // IEntityManager - manages access to database with fluent statements
@@ -17,6 +20,13 @@ type IEntityManager interface {
 	// Transaction starts a transaction using the underlying db connection
 	Transaction() (*sql.Tx, error)
 
+	// TransactionContext starts a transaction bound to a context using the underlying db connection
+	TransactionContext(ctx context.Context, options *sql.TxOptions) (*sql.Tx, error)
+
+	// RunInTransaction runs an operation in a transaction which is committed on success and rolled back on failure.
+	// If the entitymanager is already scoped to a transaction the operation is run in a savepoint.
+	RunInTransaction(ctx context.Context, options *TransactionOptions, operation func(transaction *EntityManager) error) error
+
 	// loads entities from the database
 	LoadEntities(model *models.EntityModel) *statements.LoadStatement
 
@@ -26,6 +36,9 @@ type IEntityManager interface {
 	// inserts data into a table
 	Insert(model *models.EntityModel) *statements.InsertStatement
 
+	// inserts data into a table updating existing rows on conflict
+	Upsert(model *models.EntityModel) *statements.UpsertStatement
+
 	// updates data of a table
 	Update(model *models.EntityModel) *statements.UpdateStatement
 
@@ -35,28 +48,125 @@ type IEntityManager interface {
 	// deletes rows from a database
 	Delete(model *models.EntityModel) *statements.DeleteStatement
 
+	// inserts an entity into a table
+	InsertEntity(model *models.EntityModel, entity interface{}) error
+
+	// inserts an entity into a table using a context
+	InsertEntityContext(ctx context.Context, model *models.EntityModel, entity interface{}) error
+
+	// updates the row of an entity identified by its primary key
+	UpdateEntity(model *models.EntityModel, entity interface{}) (int64, error)
+
+	// updates the row of an entity identified by its primary key using a context
+	UpdateEntityContext(ctx context.Context, model *models.EntityModel, entity interface{}) (int64, error)
+
+	// deletes the row of an entity identified by its primary key
+	DeleteEntity(model *models.EntityModel, entity interface{}) (int64, error)
+
+	// deletes the row of an entity identified by its primary key using a context
+	DeleteEntityContext(ctx context.Context, model *models.EntityModel, entity interface{}) (int64, error)
+
+	// inserts an entity or updates its row if it already exists
+	SaveEntity(model *models.EntityModel, entity interface{}) error
+
+	// inserts an entity or updates its row if it already exists using a context
+	SaveEntityContext(ctx context.Context, model *models.EntityModel, entity interface{}) error
+
 	// updates schema of a table in database (or creates it)
 	UpdateSchema(model *models.EntityModel) error
 }
 
 // EntityManager manages access to database with fluent statements using a database connection
 type EntityManager struct {
-	connection     *sql.DB                    // connection to database
+	database       *sql.DB                    // database closed by Close
+	connection     interfaces.IExecutor       // executor used to execute statements
 	connectioninfo connection.IConnectionInfo // driver specific information about database
 	schemaupdater  *SchemaUpdater
+	savepoints     int // number of savepoints enclosing operations of an entitymanager scoped to a transaction
 }
 
 // NewEntitymanager - creates a new entitymanager
 func NewEntitymanager(connection *sql.DB, connectioninfo connection.IConnectionInfo) *EntityManager {
 	return &EntityManager{
+		database:       connection,
 		connection:     connection,
 		connectioninfo: connectioninfo,
 		schemaupdater:  &SchemaUpdater{}}
 }
 
+// Scope creates an entitymanager which executes all statements using the specified executor. This way code
+//       can be run inside of a transaction or on a dedicated connection without knowing about it.
+//
+// **Parameters**
+//   - executor: executor used to execute statements, usually a *sql.Tx or *sql.Conn
+//
+// **Returns**
+//   - *EntityManager: entitymanager scoped to the executor
+func (manager *EntityManager) Scope(executor interfaces.IExecutor) *EntityManager {
+	return &EntityManager{
+		database:       manager.database,
+		connection:     executor,
+		connectioninfo: manager.connectioninfo,
+		schemaupdater:  manager.schemaupdater}
+}
+
+// Open opens a database and creates an entitymanager using the dialect registered for the sql driver
+//
+// **Parameters**
+//   - drivername: name of sql driver as used in sql.Open
+//   - dsn:        driver specific data source name
+//
+// **Returns**
+//   - *EntityManager: entitymanager for the opened database
+//   - error:          error if no dialect is registered for the driver or database could not get opened
+func Open(drivername string, dsn string) (*EntityManager, error) {
+	connectioninfo, err := connection.Resolve(drivername)
+	if err != nil {
+		return nil, err
+	}
+
+	database, err := sql.Open(drivername, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to open database: %w", err)
+	}
+
+	return NewEntitymanager(database, connectioninfo), nil
+}
+
+// Close closes the underlying db connection. Scoped entitymanagers don't own their executor so closing them does nothing.
+//
+// **Returns**
+//   - error: error if connection could not get closed
+func (manager *EntityManager) Close() error {
+	if manager.connection != manager.database {
+		return nil
+	}
+
+	return manager.database.Close()
+}
+
 // Transaction starts a transaction using the underlying db connection
 func (manager *EntityManager) Transaction() (*sql.Tx, error) {
-	return manager.connection.BeginTx(context.Background(), &sql.TxOptions{})
+	return manager.TransactionContext(context.Background(), &sql.TxOptions{})
+}
+
+// TransactionContext starts a transaction using the underlying db connection. The transaction is rolled back
+//                    if the context is cancelled before the transaction is committed.
+//
+// **Parameters**
+//   - ctx:     context the transaction is bound to
+//   - options: isolation level and read only flag of the transaction, nil for default options
+//
+// **Returns**
+//   - *sql.Tx: started transaction
+//   - error:   error if transaction could not get started
+func (manager *EntityManager) TransactionContext(ctx context.Context, options *sql.TxOptions) (*sql.Tx, error) {
+	starter, ok := manager.connection.(transactor)
+	if !ok {
+		return nil, fmt.Errorf("Unable to start a transaction using an executor of type %T", manager.connection)
+	}
+
+	return starter.BeginTx(ctx, options)
 }
 
 // LoadEntities loads entities from the database
@@ -109,6 +219,17 @@ func (manager *EntityManager) Insert(model *models.EntityModel) *statements.Inse
 	return statements.NewInsertStatement(model, manager.connection, manager.connectioninfo)
 }
 
+// Upsert creates a statement used to insert entity data into a database updating existing rows on conflict
+//
+// **Parameters**
+//   - model: model of entity for which to insert data
+//
+// **Returns**
+//   - *UpsertStatement: statement to use to prepare upsert operation
+func (manager *EntityManager) Upsert(model *models.EntityModel) *statements.UpsertStatement {
+	return statements.NewUpsertStatement(model, manager.connection, manager.connectioninfo)
+}
+
 // Update creates an update statement used to update entity data in the database
 //
 // **Parameters**
@@ -131,6 +252,292 @@ func (manager *EntityManager) Delete(model *models.EntityModel) *statements.Dele
 	return statements.NewDeleteStatement(model, manager.connection, manager.connectioninfo)
 }
 
+// InsertEntity inserts an entity into the table of its model. Autoincrement columns are skipped
+//              and the generated identity is written back to the entity.
+//
+// **Parameters**
+//   - model:  model of entity to insert
+//   - entity: pointer to entity to insert
+//
+// **Returns**
+//   - error: error if entity could not get inserted
+func (manager *EntityManager) InsertEntity(model *models.EntityModel, entity interface{}) error {
+	return manager.InsertEntityContext(context.Background(), model, entity)
+}
+
+// InsertEntityContext inserts an entity into the table of its model like InsertEntity using a context
+//
+// **Parameters**
+//   - ctx:    context used to cancel execution
+//   - model:  model of entity to insert
+//   - entity: pointer to entity to insert
+//
+// **Returns**
+//   - error: error if entity could not get inserted
+func (manager *EntityManager) InsertEntityContext(ctx context.Context, model *models.EntityModel, entity interface{}) error {
+	value, err := entityValue(model, entity)
+	if err != nil {
+		return err
+	}
+
+	var fields []string
+	var arguments []interface{}
+	for _, column := range model.Columns() {
+		if column.IsAutoIncrement() {
+			continue
+		}
+
+		fields = append(fields, column.Field())
+		arguments = append(arguments, value.FieldByName(column.Field()).Interface())
+	}
+
+	statement := manager.Insert(model).Columns(fields...)
+
+	identity := model.Identity()
+	if identity == nil {
+		_, err = statement.Prepare().ExecuteContext(ctx, arguments...)
+		return err
+	}
+
+	id, err := statement.ReturnID().Prepare().ExecuteContext(ctx, arguments...)
+	if err != nil {
+		return err
+	}
+
+	field := value.FieldByName(identity.Field())
+	if field.Kind() == reflect.Ptr {
+		field.Set(reflect.New(field.Type().Elem()))
+		field = field.Elem()
+	}
+
+	if scanner, ok := field.Addr().Interface().(sql.Scanner); ok {
+		// nullable types like sql.NullInt64
+		return scanner.Scan(id)
+	}
+
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		field.SetInt(id)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		field.SetUint(uint64(id))
+	default:
+		return fmt.Errorf("Unable to write identity to field '%s' of type %s", identity.Field(), field.Type())
+	}
+
+	return nil
+}
+
+// UpdateEntity updates all columns of the row of an entity which is identified by its primary key
+//
+// **Parameters**
+//   - model:  model of entity to update
+//   - entity: pointer to entity to update
+//
+// **Returns**
+//   - int64: number of affected rows
+//   - error: error if entity could not get updated
+func (manager *EntityManager) UpdateEntity(model *models.EntityModel, entity interface{}) (int64, error) {
+	return manager.UpdateEntityContext(context.Background(), model, entity)
+}
+
+// UpdateEntityContext updates all columns of the row of an entity which is identified by its primary key
+//
+// **Parameters**
+//   - ctx:    context used to cancel execution
+//   - model:  model of entity to update
+//   - entity: pointer to entity to update
+//
+// **Returns**
+//   - int64: number of affected rows
+//   - error: error if entity could not get updated
+func (manager *EntityManager) UpdateEntityContext(ctx context.Context, model *models.EntityModel, entity interface{}) (int64, error) {
+	value, err := entityValue(model, entity)
+	if err != nil {
+		return 0, err
+	}
+
+	columns := updatableColumns(model)
+	if len(columns) == 0 {
+		return 0, fmt.Errorf("Model '%s' has no columns to update besides its primary key", model.Table)
+	}
+
+	var updates []interface{}
+	var arguments []interface{}
+	for _, column := range columns {
+		updates = append(updates, xpr.Assign(xpr.Field(model, column.Field()), xpr.Parameter()))
+		arguments = append(arguments, value.FieldByName(column.Field()).Interface())
+	}
+
+	predicate, keys, err := primaryKeyPredicate(model, value)
+	if err != nil {
+		return 0, err
+	}
+
+	return manager.Update(model).Set(updates...).Where(predicate).Prepare().ExecuteContext(ctx, append(arguments, keys...)...)
+}
+
+// DeleteEntity deletes the row of an entity which is identified by its primary key
+//
+// **Parameters**
+//   - model:  model of entity to delete
+//   - entity: pointer to entity to delete
+//
+// **Returns**
+//   - int64: number of affected rows
+//   - error: error if entity could not get deleted
+func (manager *EntityManager) DeleteEntity(model *models.EntityModel, entity interface{}) (int64, error) {
+	return manager.DeleteEntityContext(context.Background(), model, entity)
+}
+
+// DeleteEntityContext deletes the row of an entity which is identified by its primary key
+//
+// **Parameters**
+//   - ctx:    context used to cancel execution
+//   - model:  model of entity to delete
+//   - entity: pointer to entity to delete
+//
+// **Returns**
+//   - int64: number of affected rows
+//   - error: error if entity could not get deleted
+func (manager *EntityManager) DeleteEntityContext(ctx context.Context, model *models.EntityModel, entity interface{}) (int64, error) {
+	value, err := entityValue(model, entity)
+	if err != nil {
+		return 0, err
+	}
+
+	predicate, keys, err := primaryKeyPredicate(model, value)
+	if err != nil {
+		return 0, err
+	}
+
+	return manager.Delete(model).Where(predicate).Prepare().ExecuteContext(ctx, keys...)
+}
+
+// SaveEntity inserts an entity or updates its row if it already exists. For models with an identity column
+//            the entity is inserted if the identity is not set. Otherwise the row is updated and an error is
+//            returned if no row with the identity exists. For models without an identity column the entity
+//            is inserted if no row with its primary key exists.
+//
+// **Parameters**
+//   - model:  model of entity to save
+//   - entity: pointer to entity to save
+//
+// **Returns**
+//   - error: error if entity could not get saved
+func (manager *EntityManager) SaveEntity(model *models.EntityModel, entity interface{}) error {
+	return manager.SaveEntityContext(context.Background(), model, entity)
+}
+
+// SaveEntityContext inserts an entity or updates its row if it already exists like SaveEntity using a context
+//
+// **Parameters**
+//   - ctx:    context used to cancel execution
+//   - model:  model of entity to save
+//   - entity: pointer to entity to save
+//
+// **Returns**
+//   - error: error if entity could not get saved
+func (manager *EntityManager) SaveEntityContext(ctx context.Context, model *models.EntityModel, entity interface{}) error {
+	value, err := entityValue(model, entity)
+	if err != nil {
+		return err
+	}
+
+	identity := model.Identity()
+	if identity != nil && value.FieldByName(identity.Field()).IsZero() {
+		return manager.InsertEntityContext(ctx, model, entity)
+	}
+
+	if len(updatableColumns(model)) > 0 {
+		affected, err := manager.UpdateEntityContext(ctx, model, entity)
+		if err != nil {
+			return err
+		}
+
+		if affected > 0 {
+			return nil
+		}
+	}
+
+	// databases like mysql only count rows which actually changed, so no affected rows don't mean the row is missing
+	exists, err := manager.existsEntity(ctx, model, value)
+	if err != nil {
+		return err
+	}
+
+	if exists {
+		return nil
+	}
+
+	if identity != nil {
+		return fmt.Errorf("No row with identity %v exists in table '%s'", value.FieldByName(identity.Field()).Interface(), model.Table)
+	}
+
+	return manager.InsertEntityContext(ctx, model, entity)
+}
+
+// existsEntity determines whether a row with the primary key of an entity exists
+func (manager *EntityManager) existsEntity(ctx context.Context, model *models.EntityModel, value reflect.Value) (bool, error) {
+	predicate, keys, err := primaryKeyPredicate(model, value)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := manager.Load(model, xpr.Field(model, model.PrimaryKeys()[0].Field())).Where(predicate).Prepare().ExecuteContext(ctx, keys...)
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+
+	return rows.Next(), rows.Err()
+}
+
+// updatableColumns provides the columns of a model which are updated when an entity is updated
+func updatableColumns(model *models.EntityModel) []*models.ColumnDescriptor {
+	var columns []*models.ColumnDescriptor
+	for _, column := range model.Columns() {
+		if column.IsPrimaryKey() || column.IsAutoIncrement() {
+			continue
+		}
+
+		columns = append(columns, column)
+	}
+	return columns
+}
+
+// entityValue provides the struct value of an entity pointer
+func entityValue(model *models.EntityModel, entity interface{}) (reflect.Value, error) {
+	value := reflect.ValueOf(entity)
+	if value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Type() != model.EntityType() {
+		return reflect.Value{}, fmt.Errorf("Entity has to be a pointer to %s but is %T", model.EntityType(), entity)
+	}
+
+	return value.Elem(), nil
+}
+
+// primaryKeyPredicate creates a predicate matching the primary key of an entity and the arguments to fill it
+func primaryKeyPredicate(model *models.EntityModel, value reflect.Value) (interface{}, []interface{}, error) {
+	keys := model.PrimaryKeys()
+	if len(keys) == 0 {
+		return nil, nil, fmt.Errorf("Model '%s' has no primary key", model.Table)
+	}
+
+	var predicate interface{}
+	var arguments []interface{}
+	for _, key := range keys {
+		match := xpr.Equals(xpr.Field(model, key.Field()), xpr.Parameter())
+		if predicate == nil {
+			predicate = match
+		} else {
+			predicate = xpr.And(predicate, match)
+		}
+
+		arguments = append(arguments, value.FieldByName(key.Field()).Interface())
+	}
+
+	return predicate, arguments, nil
+}
+
 // Exists determines whether an entity has a table or view in database
 //
 // **Parameters**
@@ -168,7 +575,9 @@ func (manager *EntityManager) Create(model *models.EntityModel) error {
 	return nil
 }
 
-// UpdateSchema updates the schema of an entity in database
+// UpdateSchema updates the schema of an entity in database. Schema changes are applied using the executor of the entitymanager,
+//              in a savepoint if the entitymanager is scoped to a transaction. Be aware that mysql commits transactions
+//              implicitly when changing the schema.
 //
 // **Parameters**
 //   - model: model of entity to update in database
@@ -192,12 +601,13 @@ func (manager *EntityManager) UpdateSchema(model *models.EntityModel) error {
 
 	schema, err := manager.connectioninfo.GetSchema(manager.connection, model.Table)
 	if err != nil {
-		return fmt.Errorf("Unable to get schema information: %s", err.Error())
+		return fmt.Errorf("Unable to get schema information: %w", err)
 	}
 
 	updater := &SchemaUpdater{
 		connection:     manager.connection,
-		connectioninfo: manager.connectioninfo}
+		connectioninfo: manager.connectioninfo,
+		savepoints:     manager.savepoints}
 
 	switch schema.Type() {
 	case models.SchemaTypeTable:
@@ -209,7 +619,7 @@ func (manager *EntityManager) UpdateSchema(model *models.EntityModel) error {
 	}
 
 	if err != nil {
-		return fmt.Errorf("Unable to update schema: %s", err.Error())
+		return fmt.Errorf("Unable to update schema: %w", err)
 	}
 
 	return nil
@@ -1,6 +1,7 @@
 package entities
 
 import (
+	"context"
 	"database/sql"
 	"reflect"
 	"testing"
@@ -29,6 +30,26 @@ type TestEntity struct {
 	Counter int
 }
 
+type IdentityEntity struct {
+	ID      int64 `database:"primarykey,autoincrement"`
+	Name    string
+	Counter int
+}
+
+type KeyEntity struct {
+	Key   string `database:"primarykey"`
+	Value string
+}
+
+type TagEntity struct {
+	Name string `database:"primarykey"`
+}
+
+type RequiredEntity struct {
+	Data     string
+	Required string `database:"notnull"`
+}
+
 func TestExists(t *testing.T) {
 	database, err := sql.Open("sqlite3", ":memory:")
 	assert.NoError(t, err)
@@ -226,3 +247,311 @@ func TestSchemaUpdateNewColumn(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, int64(1), affected)
 }
+
+func TestSchemaUpdateNewColumnError(t *testing.T) {
+	database, err := sql.Open("sqlite3", ":memory:")
+
+	assert.NoError(t, err)
+	connectioninfo := connection.NewSqliteInfo()
+
+	defer database.Close()
+
+	_, err = database.Exec("CREATE TABLE requiredentity (id INTEGER)")
+	assert.NoError(t, err)
+	_, err = database.Exec("INSERT INTO requiredentity (id) VALUES (1)")
+	assert.NoError(t, err)
+
+	updater := &SchemaUpdater{
+		connection:     database,
+		connectioninfo: connectioninfo}
+
+	model := models.CreateModel(reflect.TypeOf(RequiredEntity{}))
+
+	// sqlite is unable to add a column which is not nullable without a default value to a table containing rows
+	err = updater.UpdateTable(model, models.NewTableDescriptor("requiredentity", nil, nil, nil))
+	assert.Error(t, err)
+
+	schema, err := connectioninfo.GetSchema(database, "requiredentity")
+	assert.NoError(t, err)
+	assert.Len(t, schema.(*models.Table).Columns(), 1)
+}
+
+func TestOpen(t *testing.T) {
+	entitymanager, err := Open("sqlite3", ":memory:")
+	assert.NoError(t, err)
+
+	defer entitymanager.Close()
+
+	model := models.CreateModel(reflect.TypeOf(TestEntity{}))
+
+	err = entitymanager.Create(model)
+	assert.NoError(t, err)
+
+	result, err := entitymanager.Exists(model)
+	assert.NoError(t, err)
+	assert.True(t, result)
+}
+
+func TestOpenUnknownDriver(t *testing.T) {
+	entitymanager, err := Open("unknowndriver", "")
+	assert.Error(t, err)
+	assert.Nil(t, entitymanager)
+}
+
+func TestInsertUpdateDeleteEntity(t *testing.T) {
+	database, err := sql.Open("sqlite3", ":memory:")
+	assert.NoError(t, err)
+
+	defer database.Close()
+
+	entitymanager := NewEntitymanager(database, connection.NewSqliteInfo())
+
+	model := models.CreateModel(reflect.TypeOf(IdentityEntity{}))
+	assert.NoError(t, entitymanager.Create(model))
+
+	first := &IdentityEntity{Name: "first", Counter: 1}
+	second := &IdentityEntity{Name: "second", Counter: 2}
+	assert.NoError(t, entitymanager.InsertEntity(model, first))
+	assert.NoError(t, entitymanager.InsertEntity(model, second))
+	assert.Equal(t, int64(1), first.ID)
+	assert.Equal(t, int64(2), second.ID)
+
+	second.Counter = 7
+	affected, err := entitymanager.UpdateEntity(model, second)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), affected)
+
+	affected, err = entitymanager.DeleteEntity(model, first)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), affected)
+
+	result, err := entitymanager.LoadEntities(model).Prepare().ExecuteEntity()
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{&IdentityEntity{ID: 2, Name: "second", Counter: 7}}, result)
+}
+
+func TestSaveEntity(t *testing.T) {
+	database, err := sql.Open("sqlite3", ":memory:")
+	assert.NoError(t, err)
+
+	defer database.Close()
+
+	entitymanager := NewEntitymanager(database, connection.NewSqliteInfo())
+
+	identitymodel := models.CreateModel(reflect.TypeOf(IdentityEntity{}))
+	keymodel := models.CreateModel(reflect.TypeOf(KeyEntity{}))
+	assert.NoError(t, entitymanager.Create(identitymodel))
+	assert.NoError(t, entitymanager.Create(keymodel))
+
+	entity := &IdentityEntity{Name: "entity"}
+	assert.NoError(t, entitymanager.SaveEntity(identitymodel, entity))
+	entity.Counter = 3
+	assert.NoError(t, entitymanager.SaveEntity(identitymodel, entity))
+
+	keyentity := &KeyEntity{Key: "key", Value: "first"}
+	assert.NoError(t, entitymanager.SaveEntity(keymodel, keyentity))
+	keyentity.Value = "second"
+	assert.NoError(t, entitymanager.SaveEntity(keymodel, keyentity))
+
+	result, err := entitymanager.LoadEntities(identitymodel).Prepare().ExecuteEntity()
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{&IdentityEntity{ID: 1, Name: "entity", Counter: 3}}, result)
+
+	result, err = entitymanager.LoadEntities(keymodel).Prepare().ExecuteEntity()
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{&KeyEntity{Key: "key", Value: "second"}}, result)
+}
+
+func TestSaveEntityWithoutRow(t *testing.T) {
+	database, err := sql.Open("sqlite3", ":memory:")
+	assert.NoError(t, err)
+
+	defer database.Close()
+
+	entitymanager := NewEntitymanager(database, connection.NewSqliteInfo())
+
+	identitymodel := models.CreateModel(reflect.TypeOf(IdentityEntity{}))
+	assert.NoError(t, entitymanager.Create(identitymodel))
+
+	// saving an unchanged entity doesn't affect rows on some databases but the row exists
+	entity := &IdentityEntity{Name: "entity"}
+	assert.NoError(t, entitymanager.SaveEntity(identitymodel, entity))
+	assert.NoError(t, entitymanager.SaveEntity(identitymodel, entity))
+
+	assert.Error(t, entitymanager.SaveEntity(identitymodel, &IdentityEntity{ID: 7, Name: "missing"}))
+
+	tagmodel := models.CreateModel(reflect.TypeOf(TagEntity{}))
+	assert.NoError(t, entitymanager.Create(tagmodel))
+
+	// models only consisting of primary keys have nothing to update
+	tag := &TagEntity{Name: "tag"}
+	_, err = entitymanager.UpdateEntity(tagmodel, tag)
+	assert.Error(t, err)
+
+	assert.NoError(t, entitymanager.SaveEntity(tagmodel, tag))
+	assert.NoError(t, entitymanager.SaveEntity(tagmodel, tag))
+
+	result, err := entitymanager.LoadEntities(tagmodel).Prepare().ExecuteEntity()
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{&TagEntity{Name: "tag"}}, result)
+}
+
+func TestEntityOperationErrors(t *testing.T) {
+	database, err := sql.Open("sqlite3", ":memory:")
+	assert.NoError(t, err)
+
+	defer database.Close()
+
+	entitymanager := NewEntitymanager(database, connection.NewSqliteInfo())
+
+	model := models.CreateModel(reflect.TypeOf(TestEntity{}))
+
+	assert.Error(t, entitymanager.InsertEntity(model, TestEntity{}))
+	assert.Error(t, entitymanager.InsertEntity(model, &IdentityEntity{}))
+
+	_, err = entitymanager.UpdateEntity(model, &TestEntity{})
+	assert.Error(t, err)
+
+	_, err = entitymanager.DeleteEntity(model, &TestEntity{})
+	assert.Error(t, err)
+}
+
+func TestContextOperations(t *testing.T) {
+	database, err := sql.Open("sqlite3", ":memory:")
+	assert.NoError(t, err)
+
+	defer database.Close()
+
+	entitymanager := NewEntitymanager(database, connection.NewSqliteInfo())
+
+	model := models.CreateModel(reflect.TypeOf(IdentityEntity{}))
+	assert.NoError(t, entitymanager.Create(model))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	entity := &IdentityEntity{Name: "entity"}
+	assert.NoError(t, entitymanager.InsertEntityContext(ctx, model, entity))
+	assert.Equal(t, int64(1), entity.ID)
+
+	transaction, err := entitymanager.TransactionContext(ctx, &sql.TxOptions{})
+	assert.NoError(t, err)
+	_, err = entitymanager.Insert(model).Columns("Name", "Counter").Prepare().ExecuteTransactionContext(ctx, transaction, "cancelled", 0)
+	assert.NoError(t, err)
+
+	// cancelling the context rolls back the transaction and aborts further executions
+	cancel()
+	assert.Error(t, transaction.Commit())
+
+	_, err = entitymanager.LoadEntities(model).Prepare().ExecuteEntityContext(ctx)
+	assert.Error(t, err)
+	assert.Error(t, entitymanager.SaveEntityContext(ctx, model, &IdentityEntity{Name: "cancelled"}))
+}
+
+func TestScopedEntityManager(t *testing.T) {
+	database, err := sql.Open("sqlite3", ":memory:")
+	assert.NoError(t, err)
+
+	defer database.Close()
+	database.SetMaxOpenConns(1)
+
+	entitymanager := NewEntitymanager(database, connection.NewSqliteInfo())
+
+	model := models.CreateModel(reflect.TypeOf(IdentityEntity{}))
+	assert.NoError(t, entitymanager.Create(model))
+
+	transaction, err := entitymanager.Transaction()
+	assert.NoError(t, err)
+
+	var scoped IEntityManager = entitymanager.Scope(transaction)
+	assert.NoError(t, scoped.InsertEntity(model, &IdentityEntity{Name: "rolledback"}))
+
+	count, err := scoped.Load(model, xpr.Count()).Prepare().ExecuteScalar()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	_, err = scoped.Transaction()
+	assert.Error(t, err)
+	assert.NoError(t, entitymanager.Scope(transaction).Close())
+	assert.NoError(t, transaction.Rollback())
+
+	conn, err := database.Conn(context.Background())
+	assert.NoError(t, err)
+
+	scoped = entitymanager.Scope(conn)
+	assert.NoError(t, scoped.InsertEntity(model, &IdentityEntity{Name: "committed"}))
+	assert.NoError(t, conn.Close())
+
+	result, err := entitymanager.LoadEntities(model).Prepare().ExecuteEntity()
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{&IdentityEntity{ID: 1, Name: "committed"}}, result)
+}
+
+type NullableEntity struct {
+	ID      *int64 `database:"primarykey,autoincrement"`
+	Name    *string
+	Counter *int
+	Total   sql.NullInt64
+	Comment sql.NullString
+	Key     string `database:"notnull"`
+}
+
+func TestNullableEntity(t *testing.T) {
+	database, err := sql.Open("sqlite3", ":memory:")
+	assert.NoError(t, err)
+
+	defer database.Close()
+
+	connectioninfo := connection.NewSqliteInfo()
+	entitymanager := NewEntitymanager(database, connectioninfo)
+
+	model := models.CreateModel(reflect.TypeOf(NullableEntity{}))
+	assert.NoError(t, entitymanager.Create(model))
+
+	schema, err := connectioninfo.GetSchema(database, model.Table)
+	assert.NoError(t, err)
+
+	for _, column := range schema.(*models.Table).Columns() {
+		switch column.Name() {
+		case "id", "counter", "total":
+			assert.Equal(t, "INTEGER", column.DBType())
+			assert.False(t, column.IsNotNull())
+		case "name", "comment":
+			assert.Equal(t, "TEXT", column.DBType())
+			assert.False(t, column.IsNotNull())
+		case "key":
+			assert.Equal(t, "TEXT", column.DBType())
+			assert.True(t, column.IsNotNull())
+		default:
+			assert.Failf(t, "Unexpected column '%s'", column.Name())
+		}
+	}
+
+	name := "name"
+	counter := 7
+	entity := &NullableEntity{Name: &name, Counter: &counter, Total: sql.NullInt64{Int64: 12, Valid: true}, Key: "full"}
+	assert.NoError(t, entitymanager.InsertEntity(model, entity))
+	assert.NotNil(t, entity.ID)
+	assert.Equal(t, int64(1), *entity.ID)
+
+	empty := &NullableEntity{Key: "empty"}
+	assert.NoError(t, entitymanager.InsertEntity(model, empty))
+	assert.NotNil(t, empty.ID)
+	assert.Equal(t, int64(2), *empty.ID)
+
+	entities, err := entitymanager.LoadEntities(model).OrderBy(xpr.Field(model, "ID")).Prepare().ExecuteEntity()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(entities))
+
+	loaded := entities[0].(*NullableEntity)
+	assert.Equal(t, "name", *loaded.Name)
+	assert.Equal(t, 7, *loaded.Counter)
+	assert.Equal(t, sql.NullInt64{Int64: 12, Valid: true}, loaded.Total)
+	assert.False(t, loaded.Comment.Valid)
+	assert.Equal(t, "full", loaded.Key)
+
+	loaded = entities[1].(*NullableEntity)
+	assert.Nil(t, loaded.Name)
+	assert.Nil(t, loaded.Counter)
+	assert.False(t, loaded.Total.Valid)
+	assert.False(t, loaded.Comment.Valid)
+	assert.Equal(t, "empty", loaded.Key)
+}
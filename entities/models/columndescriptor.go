@@ -12,6 +12,7 @@ type ColumnDescriptor struct {
 	isautoincrement bool
 	isnotnull       bool
 	defaultvalue    string
+	size            int
 
 	field    string
 	datatype reflect.Type
@@ -79,6 +80,14 @@ func (column *ColumnDescriptor) IsNotNull() bool {
 	return column.isnotnull
 }
 
+// Size maximum size of column values, 0 if size is not limited
+//
+// **Returns**
+//   - int: maximum size of values
+func (column *ColumnDescriptor) Size() int {
+	return column.size
+}
+
 // HasDefault determines whether column has a default value
 //
 // **Returns**
@@ -2,6 +2,7 @@ package models
 
 import (
 	"reflect"
+	"strconv"
 	"strings"
 )
 
@@ -11,9 +12,11 @@ type EntityModel struct {
 	schematype SchemaType // type of schema
 	entitytype reflect.Type
 	columns    map[string]*ColumnDescriptor
+	columnlist []*ColumnDescriptor // columns in order of declaration
 	fields     map[string]*ColumnDescriptor
 	indices    map[string]*IndexDescriptor
 	uniques    map[string]*IndexDescriptor
+	extra      string // name of field collecting values of unmapped result columns
 
 	viewsql string // sql representing view if schema type is a view
 }
@@ -56,6 +59,12 @@ func CreateModel(entitytype reflect.Type) *EntityModel {
 			datatype: field.Type}
 
 		var tag string = field.Tag.Get("database")
+		if tag == "extra" {
+			// field is no column but collects values of result columns not mapped to any other field
+			model.extra = field.Name
+			continue
+		}
+
 		if len(tag) > 0 {
 			var options []string = strings.Split(tag, ",")
 			for _, option := range options {
@@ -88,12 +97,15 @@ func CreateModel(entitytype reflect.Type) *EntityModel {
 						uniques[uniquename] = append(uniques[uniquename], descriptor.name)
 					} else if strings.HasPrefix(option, "default=") {
 						descriptor.defaultvalue = option[8:]
+					} else if strings.HasPrefix(option, "size=") {
+						descriptor.size, _ = strconv.Atoi(option[5:])
 					}
 				}
 			}
 		}
 
 		model.columns[descriptor.name] = &descriptor
+		model.columnlist = append(model.columnlist, &descriptor)
 		model.fields[field.Name] = &descriptor
 	}
 
@@ -115,17 +127,43 @@ func CreateModelWithTable(entitytype reflect.Type, table string) *EntityModel {
 	return model
 }
 
-// Columns - access to all columns in model
+// Columns - access to all columns in model in order of declaration
 func (model *EntityModel) Columns() []*ColumnDescriptor {
 	var columns []*ColumnDescriptor
 
-	for _, value := range model.columns {
+	for _, value := range model.columnlist {
 		columns = append(columns, value)
 	}
 
 	return columns
 }
 
+// Identity - column which gets its value generated by the database, nil if model has no such column
+func (model *EntityModel) Identity() *ColumnDescriptor {
+	for _, value := range model.columnlist {
+		if value.IsAutoIncrement() {
+			return value
+		}
+	}
+
+	return nil
+}
+
+// PrimaryKeys - columns which are part of the primary key in order of declaration
+//
+// **Returns**
+//   - []*ColumnDescriptor: primary key columns, empty if model has no primary key
+func (model *EntityModel) PrimaryKeys() []*ColumnDescriptor {
+	var keys []*ColumnDescriptor
+	for _, value := range model.columnlist {
+		if value.IsPrimaryKey() {
+			keys = append(keys, value)
+		}
+	}
+
+	return keys
+}
+
 // Indices index definitions of entity model
 //
 // **Returns**
@@ -160,6 +198,15 @@ func (model *EntityModel) ColumnFromField(fieldname string) *ColumnDescriptor {
 	return model.fields[fieldname]
 }
 
+// Extra name of the field tagged as `database:"extra"` which collects values of result columns
+//       not mapped to any other field. The field has to be of type map[string]interface{}.
+//
+// **Returns**
+//   - string: name of field, empty if model has no such field
+func (model *EntityModel) Extra() string {
+	return model.extra
+}
+
 // EntityType - get entity type information used to create model
 func (model *EntityModel) EntityType() reflect.Type {
 	return model.entitytype
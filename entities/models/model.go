@@ -0,0 +1,28 @@
+package models
+
+import "reflect"
+
+// Model entity model of the entity type T. Embeds the untyped EntityModel which is
+// passed to statements using the EntityModel field.
+type Model[T any] struct {
+	*EntityModel
+}
+
+// NewModel creates an entity model for the entity type T
+//
+// **Returns**
+//   - *Model[T]: created entity model
+func NewModel[T any]() *Model[T] {
+	return &Model[T]{EntityModel: CreateModel(reflect.TypeOf((*T)(nil)).Elem())}
+}
+
+// NewModelWithTable creates an entity model for the entity type T stored in a custom table
+//
+// **Parameters**
+//   - table: name of table containing entities
+//
+// **Returns**
+//   - *Model[T]: created entity model
+func NewModelWithTable[T any](table string) *Model[T] {
+	return &Model[T]{EntityModel: CreateModelWithTable(reflect.TypeOf((*T)(nil)).Elem(), table)}
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"reflect"
+)
+
+var scannertype = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+var valuertype = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+var stringtype = reflect.TypeOf("")
+
+// UnderlyingType provides the type of values stored in a field which is able to represent null values.
+//                Pointers are resolved to the type they point to and types like sql.NullString or sql.NullTime
+//                are resolved to the type of their value field. Structs and arrays implementing sql.Scanner or
+//                driver.Valuer like uuids are resolved to string since their values are usually transferred
+//                as text. Other types are returned unchanged.
+//
+// **Parameters**
+//   - datatype: type of field
+//
+// **Returns**
+//   - reflect.Type: type of values stored in field
+func UnderlyingType(datatype reflect.Type) reflect.Type {
+	for datatype.Kind() == reflect.Ptr {
+		datatype = datatype.Elem()
+	}
+
+	if datatype.Kind() != reflect.Struct && datatype.Kind() != reflect.Array {
+		return datatype
+	}
+
+	pointertype := reflect.PtrTo(datatype)
+	if !pointertype.Implements(scannertype) && !pointertype.Implements(valuertype) {
+		return datatype
+	}
+
+	// sql.Null* types consist of the value followed by a flag determining whether the value is not null
+	if datatype.Kind() == reflect.Struct && datatype.NumField() == 2 {
+		valid := datatype.Field(1)
+		if valid.Name == "Valid" && valid.Type.Kind() == reflect.Bool {
+			return UnderlyingType(datatype.Field(0).Type)
+		}
+	}
+
+	return stringtype
+}
@@ -1,7 +1,7 @@
 package entities
 
 import (
-	"database/sql"
+	"context"
 	"fmt"
 
 	"github.com/verticalgmbh/collections-go/coll"
@@ -9,12 +9,26 @@ import (
 
 	"github.com/verticalgmbh/database-go/connection"
 	"github.com/verticalgmbh/database-go/entities/models"
+	"github.com/verticalgmbh/database-go/interfaces"
 )
 
 // SchemaUpdater updates a schema in database
 type SchemaUpdater struct {
-	connection     *sql.DB
+	connection     interfaces.IExecutor
 	connectioninfo connection.IConnectionInfo
+	savepoints     int // number of savepoints enclosing the executor if it is a transaction
+}
+
+// runTransaction runs schema changes in a transaction or in a savepoint if the executor is a transaction already
+func (updater *SchemaUpdater) runTransaction(operation func(transaction interfaces.IExecutor) error) error {
+	manager := &EntityManager{
+		connection:     updater.connection,
+		connectioninfo: updater.connectioninfo,
+		savepoints:     updater.savepoints}
+
+	return manager.RunInTransaction(context.Background(), nil, func(transaction *EntityManager) error {
+		return operation(transaction.connection)
+	})
 }
 
 // UpdateView updates a view in database
@@ -26,17 +40,19 @@ type SchemaUpdater struct {
 // **Result**
 //   - error: error if any occured
 func (updater *SchemaUpdater) UpdateView(newmodel *models.EntityModel, oldschema *models.View) error {
-	_, err := updater.connection.Exec(fmt.Sprintf("DROP VIEW %s", oldschema.SchemaName()))
-	if err != nil {
-		return fmt.Errorf("Error updating view '%s': %s", oldschema.SchemaName(), err.Error())
-	}
+	return updater.runTransaction(func(transaction interfaces.IExecutor) error {
+		_, err := transaction.ExecContext(context.Background(), fmt.Sprintf("DROP VIEW %s", oldschema.SchemaName()))
+		if err != nil {
+			return fmt.Errorf("Error updating view '%s': %w", oldschema.SchemaName(), err)
+		}
 
-	_, err = updater.connection.Exec(newmodel.ViewSQL())
-	if err != nil {
-		return fmt.Errorf("Error updating view '%s': %s", oldschema.SchemaName(), err.Error())
-	}
+		_, err = transaction.ExecContext(context.Background(), newmodel.ViewSQL())
+		if err != nil {
+			return fmt.Errorf("Error updating view '%s': %w", oldschema.SchemaName(), err)
+		}
 
-	return nil
+		return nil
+	})
 }
 
 func (updater *SchemaUpdater) getMissingColumns(newmodel *models.EntityModel, oldschema *models.Table) []*models.ColumnDescriptor {
@@ -147,45 +163,8 @@ func (updater *SchemaUpdater) containsIndex(index *models.IndexDescriptor, index
 }
 
 func (updater *SchemaUpdater) recreateTable(newmodel *models.EntityModel, oldschema *models.Table) error {
-	/*transaction, err := updater.connection.Begin()
-	if err != nil {
-		return fmt.Errorf("Error creating transaction: %s", err.Error())
-	}*/
-
 	backupname := fmt.Sprintf("%s_original", newmodel.Table)
 
-	schemas, err := updater.connectioninfo.GetSchemas(updater.connection)
-
-	exists, err := updater.connectioninfo.ExistsTableOrView(updater.connection, backupname)
-	if err != nil {
-		return fmt.Errorf("Error checking for table: %s", err.Error())
-	}
-
-	schemas, err = updater.connectioninfo.GetSchemas(updater.connection)
-
-	if exists {
-		_, err = statements.NewDropTable(updater.connection, updater.connectioninfo, backupname).Prepare().Execute()
-		if err != nil {
-			return fmt.Errorf("Error removing old backup table: %s", err.Error())
-		}
-	}
-
-	schemas, err = updater.connectioninfo.GetSchemas(updater.connection)
-
-	_, err = statements.NewRenameTable(updater.connection, updater.connectioninfo, newmodel.Table, backupname).Prepare().Execute()
-	if err != nil {
-		return fmt.Errorf("Error renaming table: %s", err.Error())
-	}
-
-	schemas, err = updater.connectioninfo.GetSchemas(updater.connection)
-
-	_, err = statements.NewCreateStatement(newmodel, updater.connection, updater.connectioninfo).Prepare().Execute()
-	if err != nil {
-		return fmt.Errorf("Error creating new table: %s", err.Error())
-	}
-
-	schemas, err = updater.connectioninfo.GetSchemas(updater.connection)
-
 	var remaining []*models.ColumnDescriptor
 
 	coll.AddToWhere(oldschema.Columns(), func(item interface{}) bool {
@@ -204,31 +183,45 @@ func (updater *SchemaUpdater) recreateTable(newmodel *models.EntityModel, oldsch
 		})
 	}, &remaining)
 
-	_, err = statements.NewInsertLoad(newmodel, updater.connection, updater.connectioninfo).Columns(remaining...).Load(
-		statements.NewLoadStatement(updater.connection, updater.connectioninfo).Columns(remaining).Table(backupname)).Prepare().Execute()
-	if err != nil {
-		return fmt.Errorf("Error inserting existing data into new table: %s", err.Error())
-	}
+	return updater.runTransaction(func(transaction interfaces.IExecutor) error {
+		exists, err := updater.connectioninfo.ExistsTableOrView(transaction, backupname)
+		if err != nil {
+			return fmt.Errorf("Error checking for table: %w", err)
+		}
+
+		if exists {
+			_, err := statements.NewDropTable(updater.connection, updater.connectioninfo, backupname).Prepare().ExecuteExecutorContext(context.Background(), transaction)
+			if err != nil {
+				return fmt.Errorf("Error removing old backup table: %w", err)
+			}
+		}
 
-	schemas, err = updater.connectioninfo.GetSchemas(updater.connection)
+		_, err = statements.NewRenameTable(updater.connection, updater.connectioninfo, newmodel.Table, backupname).Prepare().ExecuteExecutorContext(context.Background(), transaction)
+		if err != nil {
+			return fmt.Errorf("Error renaming table: %w", err)
+		}
 
-	_, err = statements.NewDropTable(updater.connection, updater.connectioninfo, backupname).Prepare().Execute()
-	if err != nil {
-		return fmt.Errorf("Error removing old backup table: %s", err.Error())
-	}
+		_, err = statements.NewCreateStatement(newmodel, updater.connection, updater.connectioninfo).Prepare().ExecuteExecutorContext(context.Background(), transaction)
+		if err != nil {
+			return fmt.Errorf("Error creating new table: %w", err)
+		}
+
+		_, err = statements.NewInsertLoad(newmodel, updater.connection, updater.connectioninfo).Columns(remaining...).Load(
+			statements.NewLoadStatement(updater.connection, updater.connectioninfo).Columns(remaining).Table(backupname)).Prepare().ExecuteExecutorContext(context.Background(), transaction)
+		if err != nil {
+			return fmt.Errorf("Error inserting existing data into new table: %w", err)
+		}
+
+		_, err = statements.NewDropTable(updater.connection, updater.connectioninfo, backupname).Prepare().ExecuteExecutorContext(context.Background(), transaction)
+		if err != nil {
+			return fmt.Errorf("Error removing old backup table: %w", err)
+		}
 
-	schemas, err = updater.connectioninfo.GetSchemas(updater.connection)
-	if schemas != nil {
 		return nil
-	}
-	/*err = transaction.Commit()
-	if err != nil {
-		return fmt.Errorf("Error commiting transaction: %s", err.Error())
-	}*/
-	return nil
+	})
 }
 
-func (updater *SchemaUpdater) updateIndices(newmodel *models.EntityModel, oldschema *models.Table, transaction *sql.Tx) error {
+func (updater *SchemaUpdater) updateIndices(newmodel *models.EntityModel, oldschema *models.Table, transaction interfaces.IExecutor) error {
 	for _, index := range oldschema.Indices() {
 		existing := coll.FirstOrDefault(newmodel.Indices(), func(iitem interface{}) bool {
 			item := iitem.(*models.ColumnDescriptor)
@@ -236,20 +229,20 @@ func (updater *SchemaUpdater) updateIndices(newmodel *models.EntityModel, oldsch
 		}).(*models.IndexDescriptor)
 
 		if existing == nil {
-			_, err := statements.NewDropIndex(updater.connection, updater.connectioninfo, newmodel, index.Name()).Prepare().ExecuteTransaction(transaction)
+			_, err := statements.NewDropIndex(updater.connection, updater.connectioninfo, newmodel, index.Name()).Prepare().ExecuteExecutorContext(context.Background(), transaction)
 			if err != nil {
-				return fmt.Errorf("Error dropping index: %s", err.Error())
+				return fmt.Errorf("Error dropping index: %w", err)
 			}
 		} else {
 			if !updater.indexEqual(index, existing) {
-				_, err := statements.NewDropIndex(updater.connection, updater.connectioninfo, newmodel, index.Name()).Prepare().ExecuteTransaction(transaction)
+				_, err := statements.NewDropIndex(updater.connection, updater.connectioninfo, newmodel, index.Name()).Prepare().ExecuteExecutorContext(context.Background(), transaction)
 				if err != nil {
-					return fmt.Errorf("Error dropping index: %s", err.Error())
+					return fmt.Errorf("Error dropping index: %w", err)
 				}
 
-				_, err = statements.NewCreateIndexStatement(newmodel, index, updater.connection, updater.connectioninfo).Prepare().ExecuteTransaction(transaction)
+				_, err = statements.NewCreateIndexStatement(newmodel, index, updater.connection, updater.connectioninfo).Prepare().ExecuteExecutorContext(context.Background(), transaction)
 				if err != nil {
-					return fmt.Errorf("Error creating new index: %s", err.Error())
+					return fmt.Errorf("Error creating new index: %w", err)
 				}
 			}
 		}
@@ -260,9 +253,9 @@ func (updater *SchemaUpdater) updateIndices(newmodel *models.EntityModel, oldsch
 			item := iitem.(*models.IndexDescriptor)
 			return updater.indexEqual(index, item)
 		}) {
-			_, err := statements.NewCreateIndexStatement(newmodel, index, updater.connection, updater.connectioninfo).Prepare().ExecuteTransaction(transaction)
+			_, err := statements.NewCreateIndexStatement(newmodel, index, updater.connection, updater.connectioninfo).Prepare().ExecuteExecutorContext(context.Background(), transaction)
 			if err != nil {
-				return fmt.Errorf("Error creating new index: %s", err.Error())
+				return fmt.Errorf("Error creating new index: %w", err)
 			}
 		}
 	}
@@ -287,35 +280,39 @@ func (updater *SchemaUpdater) UpdateTable(newmodel *models.EntityModel, oldschem
 	if recreatetable {
 		err := updater.recreateTable(newmodel, oldschema)
 		if err != nil {
-			return fmt.Errorf("Error recreating table: %s", err.Error())
+			return fmt.Errorf("Error recreating table: %w", err)
 		}
 	} else {
-		transaction, err := updater.connection.Begin()
-		if err != nil {
-			return fmt.Errorf("Error starting transaction: %s", err.Error())
-		}
+		err := updater.runTransaction(func(transaction interfaces.IExecutor) error {
+			if len(missing) > 0 {
+				for _, column := range missing {
+					_, err := statements.NewAddColumnStatement(updater.connection, updater.connectioninfo, newmodel, column).Prepare().ExecuteExecutorContext(context.Background(), transaction)
+					if err != nil {
+						return fmt.Errorf("Error adding column '%s': %w", column.Name(), err)
+					}
+				}
+			}
 
-		if len(missing) > 0 {
-			for _, column := range missing {
-				statements.NewAddColumnStatement(updater.connection, updater.connectioninfo, newmodel, column).Prepare().ExecuteTransaction(transaction)
+			// TODO drop obsolete uniques for postgres (sqlite does not support dropping uniques so table does get recreated there anyways)
+			for _, index := range newmodel.Indices() {
+				if !updater.containsIndex(index, oldschema.Indices()) {
+					_, err := statements.NewAddUnique(updater.connection, updater.connectioninfo, newmodel, index).Prepare().ExecuteExecutorContext(context.Background(), transaction)
+					if err != nil {
+						return fmt.Errorf("Error adding unique constraint '%s': %w", index.Name(), err)
+					}
+				}
 			}
-		}
 
-		// TODO drop obsolete uniques for postgres (sqlite does not support dropping uniques so table does get recreated there anyways)
-		for _, index := range newmodel.Indices() {
-			if !updater.containsIndex(index, oldschema.Indices()) {
-				statements.NewAddUnique(updater.connection, updater.connectioninfo, newmodel, index).Prepare().ExecuteTransaction(transaction)
+			err := updater.updateIndices(newmodel, oldschema, transaction)
+			if err != nil {
+				return fmt.Errorf("Error updating indices: %w", err)
 			}
-		}
 
-		err = updater.updateIndices(newmodel, oldschema, transaction)
-		if err != nil {
-			return fmt.Errorf("Error updating indices: %s", err.Error())
-		}
+			return nil
+		})
 
-		err = transaction.Commit()
 		if err != nil {
-			return fmt.Errorf("Error commiting transaction: %s", err.Error())
+			return err
 		}
 	}
 
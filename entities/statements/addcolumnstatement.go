@@ -1,16 +1,16 @@
 package statements
 
 import (
-	"database/sql"
 	"strings"
 
 	"github.com/verticalgmbh/database-go/connection"
 	"github.com/verticalgmbh/database-go/entities/models"
+	"github.com/verticalgmbh/database-go/interfaces"
 )
 
 // AddColumnStatement statement used to add a column to a table
 type AddColumnStatement struct {
-	connection     *sql.DB
+	connection     interfaces.IExecutor
 	connectioninfo connection.IConnectionInfo
 	model          *models.EntityModel
 	column         *models.ColumnDescriptor
@@ -26,7 +26,7 @@ type AddColumnStatement struct {
 //
 // **Returns**
 //   - *AddColumnStatement: statement used to prepare operation
-func NewAddColumnStatement(connection *sql.DB, connectioninfo connection.IConnectionInfo, model *models.EntityModel, column *models.ColumnDescriptor) *AddColumnStatement {
+func NewAddColumnStatement(connection interfaces.IExecutor, connectioninfo connection.IConnectionInfo, model *models.EntityModel, column *models.ColumnDescriptor) *AddColumnStatement {
 	return &AddColumnStatement{
 		connection:     connection,
 		connectioninfo: connectioninfo,
@@ -37,10 +37,7 @@ func NewAddColumnStatement(connection *sql.DB, connectioninfo connection.IConnec
 func (statement *AddColumnStatement) buildCommandText() string {
 	var command strings.Builder
 
-	command.WriteString("ALTER TABLE ")
-	command.WriteString(statement.model.Table)
-	command.WriteString(" ADD COLUMN ")
-	statement.connectioninfo.CreateColumn(statement.column, &command)
+	statement.connectioninfo.AddColumn(statement.model.Table, statement.column, &command)
 
 	return command.String()
 }
@@ -1,16 +1,16 @@
 package statements
 
 import (
-	"database/sql"
 	"strings"
 
 	"github.com/verticalgmbh/database-go/connection"
 	"github.com/verticalgmbh/database-go/entities/models"
+	"github.com/verticalgmbh/database-go/interfaces"
 )
 
 // AddUnique statement which adds a unique index to a table
 type AddUnique struct {
-	connection     *sql.DB
+	connection     interfaces.IExecutor
 	connectioninfo connection.IConnectionInfo
 	model          *models.EntityModel
 	unique         *models.IndexDescriptor
@@ -26,7 +26,7 @@ type AddUnique struct {
 //
 // **Returns**
 //   - *AddUnique: statement used to prepare operation
-func NewAddUnique(connection *sql.DB, connectioninfo connection.IConnectionInfo, model *models.EntityModel, unique *models.IndexDescriptor) *AddUnique {
+func NewAddUnique(connection interfaces.IExecutor, connectioninfo connection.IConnectionInfo, model *models.EntityModel, unique *models.IndexDescriptor) *AddUnique {
 	return &AddUnique{
 		connection:     connection,
 		connectioninfo: connectioninfo,
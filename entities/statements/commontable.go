@@ -0,0 +1,8 @@
+package statements
+
+// commontable common table expression to define for a load statement
+type commontable struct {
+	name      string
+	statement *LoadStatement
+	recursive *LoadStatement // recursive part which is concatenated to statement using UNION ALL, nil if not recursive
+}
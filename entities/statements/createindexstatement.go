@@ -1,17 +1,17 @@
 package statements
 
 import (
-	"database/sql"
 	"fmt"
 	"strings"
 
 	"github.com/verticalgmbh/database-go/connection"
 	"github.com/verticalgmbh/database-go/entities/models"
+	"github.com/verticalgmbh/database-go/interfaces"
 )
 
 // CreateIndexStatement statement used to prepare an operation used to create an index
 type CreateIndexStatement struct {
-	connection     *sql.DB
+	connection     interfaces.IExecutor
 	connectioninfo connection.IConnectionInfo
 	model          *models.EntityModel
 	index          *models.IndexDescriptor
@@ -27,7 +27,7 @@ type CreateIndexStatement struct {
 //
 // **Returns**
 //   -*CreateIndexStatement: statement used to prepare operation
-func NewCreateIndexStatement(model *models.EntityModel, index *models.IndexDescriptor, connection *sql.DB, connectioninfo connection.IConnectionInfo) *CreateIndexStatement {
+func NewCreateIndexStatement(model *models.EntityModel, index *models.IndexDescriptor, connection interfaces.IExecutor, connectioninfo connection.IConnectionInfo) *CreateIndexStatement {
 	return &CreateIndexStatement{
 		model:          model,
 		index:          index,
@@ -40,9 +40,12 @@ func (statement *CreateIndexStatement) buildCommandText() string {
 
 	indexname := fmt.Sprintf("idx_%s_%s", statement.model.Table, statement.index.Name())
 
-	command.WriteString("DROP INDEX IF EXISTS ")
-	command.WriteString(indexname)
-	command.WriteString(";\n")
+	// databases not able to drop an index only if it exists like mysql write nothing. Index names in mysql
+	// are only unique per table, so an index of the same name can not exist on a newly created table.
+	statement.connectioninfo.DropIndex(statement.model.Table, indexname, true, &command)
+	if command.Len() > 0 {
+		command.WriteString(";\n")
+	}
 
 	command.WriteString("CREATE INDEX ")
 	command.WriteString(indexname)
@@ -1,16 +1,16 @@
 package statements
 
 import (
-	"database/sql"
 	"strings"
 
 	"github.com/verticalgmbh/database-go/connection"
 	"github.com/verticalgmbh/database-go/entities/models"
+	"github.com/verticalgmbh/database-go/interfaces"
 )
 
 // CreateStatement statement used to create tables for models in database
 type CreateStatement struct {
-	connection     *sql.DB
+	connection     interfaces.IExecutor
 	connectioninfo connection.IConnectionInfo
 	model          *models.EntityModel
 }
@@ -23,7 +23,7 @@ type CreateStatement struct {
 //   - connectioninfo: driver specific connection info
 // **Returns**
 //   - CreateStatement: statement used to prepare create operation
-func NewCreateStatement(model *models.EntityModel, connection *sql.DB, connectioninfo connection.IConnectionInfo) *CreateStatement {
+func NewCreateStatement(model *models.EntityModel, connection interfaces.IExecutor, connectioninfo connection.IConnectionInfo) *CreateStatement {
 	return &CreateStatement{
 		connection:     connection,
 		connectioninfo: connectioninfo,
@@ -1,20 +1,21 @@
 package statements
 
 import (
-	"database/sql"
 	"strings"
 
 	"github.com/verticalgmbh/database-go/connection"
 	"github.com/verticalgmbh/database-go/entities/models"
 	"github.com/verticalgmbh/database-go/entities/walkers"
+	"github.com/verticalgmbh/database-go/interfaces"
 )
 
 // DeleteStatement statement used to delete entity data from the database
 type DeleteStatement struct {
-	connection     *sql.DB
+	connection     interfaces.IExecutor
 	connectioninfo connection.IConnectionInfo
 	model          *models.EntityModel
 	where          interface{}
+	returning      []interface{} // fields of deleted rows to return
 }
 
 // NewDeleteStatement creates a statement used to delete entities from a database
@@ -26,7 +27,7 @@ type DeleteStatement struct {
 //
 // **Returns**
 //   - DeleteStatement: statement to use to prepare operation
-func NewDeleteStatement(model *models.EntityModel, connection *sql.DB, connectioninfo connection.IConnectionInfo) *DeleteStatement {
+func NewDeleteStatement(model *models.EntityModel, connection interfaces.IExecutor, connectioninfo connection.IConnectionInfo) *DeleteStatement {
 	return &DeleteStatement{
 		connection:     connection,
 		connectioninfo: connectioninfo,
@@ -45,28 +46,72 @@ func (statement *DeleteStatement) Where(predicate interface{}) *DeleteStatement
 	return statement
 }
 
-func (statement *DeleteStatement) buildCommandText() string {
+// Returning specifies fields of deleted rows to return. Execute the statement using PrepareReturning to load the returned rows.
+//
+// **Parameters**
+//   - fields: expressions specifying fields to return, all columns of the model if no fields are specified
+//
+// **Returns**
+//   - *DeleteStatement: current statement for fluent behavior
+func (statement *DeleteStatement) Returning(fields ...interface{}) *DeleteStatement {
+	statement.returning = returningFields(statement.model, fields)
+	return statement
+}
+
+func (statement *DeleteStatement) buildCommandText(returning []interface{}) (string, error) {
 	var command strings.Builder
+	parameters := connection.NewParameters()
 
 	command.WriteString("DELETE FROM ")
 	command.WriteString(statement.model.Table)
 
+	err := writeReturning(statement.connectioninfo, connection.ReturningInline, true, returning, &command, parameters)
+	if err != nil {
+		return "", err
+	}
+
 	if statement.where != nil {
 		command.WriteString(" WHERE ")
 
-		sqlwalker := walkers.NewSqlWalker(statement.connectioninfo, &command)
-		sqlwalker.Visit(statement.where)
+		sqlwalker := walkers.NewSqlWalker(statement.connectioninfo, &command, parameters)
+		err = sqlwalker.Visit(statement.where)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	err = writeReturning(statement.connectioninfo, connection.ReturningTrailing, true, returning, &command, parameters)
+	if err != nil {
+		return "", err
 	}
 
-	return command.String()
+	return command.String(), nil
 }
 
-// Prepare prepares the statement for execution
+// Prepare prepares the statement for execution. Fields specified using Returning are not returned,
+//         use PrepareReturning to load them.
 //
 // **Returns**
 //   - PreparedStatement: statement used to execute command
 func (statement *DeleteStatement) Prepare() *PreparedStatement {
+	command, err := statement.buildCommandText(nil)
 	return &PreparedStatement{
 		connection: statement.connection,
-		command:    statement.buildCommandText()}
+		command:    command,
+		err:        err}
+}
+
+// PrepareReturning prepares the statement for execution returning fields of the deleted rows.
+//                  If no fields were specified using Returning all columns of the model are returned.
+//
+// **Returns**
+//   - *PreparedLoadStatement: statement to execute, rows can get mapped to entities using ExecuteEntity
+func (statement *DeleteStatement) PrepareReturning() *PreparedLoadStatement {
+	command, err := statement.buildCommandText(returningFields(statement.model, statement.returning))
+	return &PreparedLoadStatement{
+		command:        command,
+		err:            err,
+		connection:     statement.connection,
+		connectioninfo: statement.connectioninfo,
+		model:          statement.model}
 }
@@ -1,16 +1,16 @@
 package statements
 
 import (
-	"database/sql"
 	"strings"
 
 	"github.com/verticalgmbh/database-go/connection"
 	"github.com/verticalgmbh/database-go/entities/models"
+	"github.com/verticalgmbh/database-go/interfaces"
 )
 
 // DropIndex statement which removes an index from the database
 type DropIndex struct {
-	connection     *sql.DB
+	connection     interfaces.IExecutor
 	connectioninfo connection.IConnectionInfo
 	model          *models.EntityModel
 	name           string
@@ -26,7 +26,7 @@ type DropIndex struct {
 //
 // **Returns**
 //   - *DropIndex: statement used to prepare operation
-func NewDropIndex(connection *sql.DB, connectioninfo connection.IConnectionInfo, model *models.EntityModel, name string) *DropIndex {
+func NewDropIndex(connection interfaces.IExecutor, connectioninfo connection.IConnectionInfo, model *models.EntityModel, name string) *DropIndex {
 	return &DropIndex{
 		connection:     connection,
 		connectioninfo: connectioninfo,
@@ -37,8 +37,7 @@ func NewDropIndex(connection *sql.DB, connectioninfo connection.IConnectionInfo,
 func (statement *DropIndex) buildCommandText() string {
 	var command strings.Builder
 
-	command.WriteString("DROP INDEX ")
-	command.WriteString(statement.name)
+	statement.connectioninfo.DropIndex(statement.model.Table, statement.name, false, &command)
 
 	return command.String()
 }
@@ -1,15 +1,15 @@
 package statements
 
 import (
-	"database/sql"
 	"strings"
 
 	"github.com/verticalgmbh/database-go/connection"
+	"github.com/verticalgmbh/database-go/interfaces"
 )
 
 // DropTable statement to remove a table in a database
 type DropTable struct {
-	connection     *sql.DB
+	connection     interfaces.IExecutor
 	connectioninfo connection.IConnectionInfo
 	name           string
 }
@@ -23,7 +23,7 @@ type DropTable struct {
 //
 // **Returns**
 //   - *DropTable: statement to use to prepare operation
-func NewDropTable(connection *sql.DB, connectioninfo connection.IConnectionInfo, name string) *DropTable {
+func NewDropTable(connection interfaces.IExecutor, connectioninfo connection.IConnectionInfo, name string) *DropTable {
 	return &DropTable{
 		connection:     connection,
 		connectioninfo: connectioninfo,
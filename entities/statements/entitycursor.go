@@ -0,0 +1,56 @@
+package statements
+
+import (
+	"database/sql"
+)
+
+// EntityCursor iterates over result rows of a load statement mapping one entity at a time,
+// so large result sets can get processed without loading all entities into memory
+type EntityCursor struct {
+	rows   *sql.Rows
+	mapper *entityMapper
+	entity interface{} // entity mapped from current row
+	err    error       // error which occured when mapping a row
+}
+
+// Next advances the cursor to the next row and maps it to an entity
+//
+// **Returns**
+//   - bool: true if an entity was mapped, false if there are no more rows or an error occured
+func (cursor *EntityCursor) Next() bool {
+	cursor.entity = nil
+	if cursor.err != nil || !cursor.rows.Next() {
+		return false
+	}
+
+	cursor.entity, cursor.err = cursor.mapper.scan(cursor.rows)
+	return cursor.err == nil
+}
+
+// Entity entity mapped from the current row
+//
+// **Returns**
+//   - interface{}: pointer to mapped entity, nil if cursor is not positioned at a row
+func (cursor *EntityCursor) Entity() interface{} {
+	return cursor.entity
+}
+
+// Err error which occured while iterating over result rows
+//
+// **Returns**
+//   - error: error if a row could not get read or mapped, nil otherwise
+func (cursor *EntityCursor) Err() error {
+	if cursor.err != nil {
+		return cursor.err
+	}
+
+	return cursor.rows.Err()
+}
+
+// Close closes the result rows of the cursor. Has to be called if the cursor is not iterated to the end.
+//
+// **Returns**
+//   - error: error if rows could not get closed
+func (cursor *EntityCursor) Close() error {
+	return cursor.rows.Close()
+}
@@ -0,0 +1,141 @@
+package statements
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"unsafe"
+
+	"github.com/verticalgmbh/database-go/entities/models"
+)
+
+// entityMapper maps result rows to entities. The fields to which result columns are mapped
+// are determined once per query and reused for every row.
+type entityMapper struct {
+	model    *models.EntityModel
+	columns  []string
+	setters  []reflect.StructField // fields to which result columns are mapped, zero field for unmapped columns
+	extra    []int                 // index of field collecting values of unmapped columns, nil if values are ignored
+	values   []interface{}         // scan destinations reused for every row
+	rowindex int                   // index of next row to map
+}
+
+// newEntityMapper creates a mapper for the columns of result rows
+//
+// **Parameters**
+//   - rows:     result rows to map
+//   - model:    model of entities to create
+//   - unmapped: determines how result columns are handled which are not mapped to a field
+//
+// **Returns**
+//   - *entityMapper: mapper for result rows
+//   - error:         error if result columns could not get read or a column can not get mapped
+func newEntityMapper(rows *sql.Rows, model *models.EntityModel, unmapped UnmappedColumns) (*entityMapper, error) {
+	if model == nil {
+		return nil, errors.New("No model specified to map result rows to")
+	}
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	mapper := &entityMapper{
+		model:   model,
+		columns: columns,
+		setters: make([]reflect.StructField, len(columns)),
+		values:  make([]interface{}, len(columns))}
+
+	if unmapped == UnmappedColumnsCollect {
+		field, ok := model.EntityType().FieldByName(model.Extra())
+		if !ok || field.Type != reflect.TypeOf(map[string]interface{}{}) {
+			return nil, &MappingError{Row: -1, Err: fmt.Errorf("%s has no map[string]interface{} field tagged as extra to collect unmapped columns", model.EntityType())}
+		}
+		mapper.extra = field.Index
+	}
+
+	for index, column := range columns {
+		columndescription := model.Column(column)
+		if columndescription == nil {
+			// result columns named using xpr.As may reference the field name instead of the column name
+			columndescription = model.ColumnFromField(column)
+		}
+
+		var field reflect.StructField
+		if columndescription != nil {
+			field, _ = model.EntityType().FieldByName(columndescription.Field())
+		}
+
+		if field.Type == nil && unmapped == UnmappedColumnsError {
+			return nil, &MappingError{Row: -1, Column: column, Err: fmt.Errorf("Column is not mapped to a field of %s", model.EntityType())}
+		}
+
+		mapper.setters[index] = field
+	}
+
+	return mapper, nil
+}
+
+// scan maps the current result row to a new entity
+//
+// **Parameters**
+//   - rows: result rows positioned at the row to map
+//
+// **Returns**
+//   - interface{}: pointer to mapped entity
+//   - error:       *MappingError if row could not get scanned
+func (mapper *entityMapper) scan(rows *sql.Rows) (interface{}, error) {
+	row := mapper.rowindex
+	mapper.rowindex++
+
+	entity := reflect.New(mapper.model.EntityType()).Elem()
+
+	for index, field := range mapper.setters {
+		if field.Type == nil {
+			// column is not mapped to a field
+			mapper.values[index] = new(interface{})
+			continue
+		}
+
+		// fields are addressed directly so unexported fields can get mapped as well
+		mapper.values[index] = reflect.NewAt(field.Type, unsafe.Pointer(entity.FieldByIndex(field.Index).UnsafeAddr())).Interface()
+	}
+
+	err := rows.Scan(mapper.values...)
+	if err != nil {
+		return nil, &MappingError{Row: row, Column: mapper.scanErrorColumn(rows), Err: err}
+	}
+
+	if mapper.extra != nil {
+		extra := make(map[string]interface{})
+		for index, field := range mapper.setters {
+			if field.Type == nil {
+				extra[mapper.columns[index]] = *mapper.values[index].(*interface{})
+			}
+		}
+
+		reflect.NewAt(reflect.TypeOf(extra), unsafe.Pointer(entity.FieldByIndex(mapper.extra).UnsafeAddr())).Elem().Set(reflect.ValueOf(extra))
+	}
+
+	return entity.Addr().Interface(), nil
+}
+
+// scanErrorColumn determines the name of the column which caused a scan error. Columns of the current row
+//                 are scanned one at a time while all other columns are scanned to values accepting any type.
+func (mapper *entityMapper) scanErrorColumn(rows *sql.Rows) string {
+	values := make([]interface{}, len(mapper.values))
+	for index := range values {
+		values[index] = new(interface{})
+	}
+
+	for index, value := range mapper.values {
+		values[index] = value
+		if rows.Scan(values...) != nil {
+			return mapper.columns[index]
+		}
+		values[index] = new(interface{})
+	}
+
+	return ""
+}
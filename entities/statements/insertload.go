@@ -1,16 +1,16 @@
 package statements
 
 import (
-	"database/sql"
 	"strings"
 
 	"github.com/verticalgmbh/database-go/connection"
 	"github.com/verticalgmbh/database-go/entities/models"
+	"github.com/verticalgmbh/database-go/interfaces"
 )
 
 // InsertLoad - statement used to insert data into a database table
 type InsertLoad struct {
-	connection     *sql.DB
+	connection     interfaces.IExecutor
 	connectioninfo connection.IConnectionInfo
 	model          *models.EntityModel
 	columns        []string
@@ -18,7 +18,7 @@ type InsertLoad struct {
 }
 
 // NewInsertLoad - creates a new statement used to insert data to a database table
-func NewInsertLoad(model *models.EntityModel, connection *sql.DB, connectioninfo connection.IConnectionInfo) *InsertLoad {
+func NewInsertLoad(model *models.EntityModel, connection interfaces.IExecutor, connectioninfo connection.IConnectionInfo) *InsertLoad {
 	return &InsertLoad{model: model, connection: connection, connectioninfo: connectioninfo}
 }
 
@@ -86,9 +86,10 @@ func (statement *InsertLoad) Prepare() *PreparedStatement {
 
 	command.WriteString(") ")
 
-	command.WriteString(statement.load.buildCommand())
+	err := statement.load.writeCommand(&command, connection.NewParameters())
 
 	return &PreparedStatement{
 		command:    command.String(),
+		err:        err,
 		connection: statement.connection}
 }
@@ -1,28 +1,31 @@
 package statements
 
 import (
-	"database/sql"
+	"errors"
+	"fmt"
 	"strings"
 
 	"github.com/verticalgmbh/database-go/entities/walkers"
 
 	"github.com/verticalgmbh/database-go/connection"
 	"github.com/verticalgmbh/database-go/entities/models"
+	"github.com/verticalgmbh/database-go/interfaces"
 	"github.com/verticalgmbh/database-go/xpr"
 )
 
 // InsertStatement - statement used to insert data into a database table
 type InsertStatement struct {
-	connection     *sql.DB
+	connection     interfaces.IExecutor
 	connectioninfo connection.IConnectionInfo
 	model          *models.EntityModel
 	fields         []string
 	values         []interface{} // expression for values to insert
 	returnid       bool          // returned value contains inserted id instead of affected rows
+	returning      []interface{} // fields of inserted rows to return
 }
 
 // NewInsertStatement - creates a new statement used to insert data to a database table
-func NewInsertStatement(model *models.EntityModel, connection *sql.DB, connectioninfo connection.IConnectionInfo) *InsertStatement {
+func NewInsertStatement(model *models.EntityModel, connection interfaces.IExecutor, connectioninfo connection.IConnectionInfo) *InsertStatement {
 	return &InsertStatement{model: model, connection: connection, connectioninfo: connectioninfo}
 }
 
@@ -59,13 +62,8 @@ func (statement *InsertStatement) ReturnID() *InsertStatement {
 	return statement
 }
 
-// Prepare prepares the insert statement for execution
-//
-// **Returns**
-// - `PreparedStatement`: Statement to execute
-func (statement *InsertStatement) Prepare() *PreparedStatement {
-	var command strings.Builder
-
+// writeHeader writes the part of the insert command which precedes the values
+func (statement *InsertStatement) writeHeader(command *strings.Builder) {
 	command.WriteString("INSERT INTO ")
 	command.WriteString(statement.model.Table)
 	command.WriteString(" (")
@@ -81,25 +79,59 @@ func (statement *InsertStatement) Prepare() *PreparedStatement {
 		}
 		command.WriteString(statement.connectioninfo.MaskColumn(column.Name()))
 	}
-	command.WriteString(") ")
+	command.WriteRune(')')
+}
+
+// Returning specifies fields of inserted rows to return. Execute the statement using PrepareReturning to load the returned rows.
+//           Preparing the statement fails if Returning is combined with ReturnID.
+//
+// **Parameters**
+//   - fields: expressions specifying fields to return, all columns of the model if no fields are specified
+//
+// **Returns**
+//   - *InsertStatement: this statement for fluent behavior
+func (statement *InsertStatement) Returning(fields ...interface{}) *InsertStatement {
+	statement.returning = returningFields(statement.model, fields)
+	return statement
+}
+
+func (statement *InsertStatement) writeCommand(command *strings.Builder, returning []interface{}) error {
+	if statement.returnid && statement.returning != nil {
+		return errors.New("Returning can not get combined with ReturnID")
+	}
+
+	statement.writeHeader(command)
+
+	parameters := connection.NewParameters()
+	err := writeReturning(statement.connectioninfo, connection.ReturningInline, false, returning, command, parameters)
+	if err != nil {
+		return err
+	}
 
 	var valuestatement *PreparedLoadStatement
 	if len(statement.values) == 1 {
 		valuestatement, _ = statement.values[0].(*PreparedLoadStatement)
 	}
 
+	command.WriteRune(' ')
 	if valuestatement != nil {
-		command.WriteString(valuestatement.Command())
+		err = valuestatement.WriteCommand(command, parameters)
+		if err != nil {
+			return err
+		}
 	} else {
 		command.WriteString("VALUES(")
 		if len(statement.values) > 0 {
-			walker := walkers.NewSqlWalker(statement.connectioninfo, &command)
+			walker := walkers.NewSqlWalker(statement.connectioninfo, command, parameters)
 			for index, value := range statement.values {
 				if index > 0 {
 					command.WriteRune(',')
 				}
 
-				walker.Visit(value)
+				err := walker.Visit(value)
+				if err != nil {
+					return err
+				}
 			}
 		} else {
 
@@ -107,20 +139,79 @@ func (statement *InsertStatement) Prepare() *PreparedStatement {
 				if index > 0 {
 					command.WriteRune(',')
 				}
-				statement.connectioninfo.EvaluateParameter(xpr.Parameter(), &command)
+				err := statement.connectioninfo.EvaluateParameter(xpr.Parameter(), parameters, command)
+				if err != nil {
+					return err
+				}
 			}
 		}
 		command.WriteRune(')')
 	}
 
-	var postquery string
-	if statement.returnid {
-		postquery = statement.connectioninfo.ReturnIdentity(&command)
+	return writeReturning(statement.connectioninfo, connection.ReturningTrailing, false, returning, command, parameters)
+}
+
+// Prepare prepares the insert statement for execution. Fields specified using Returning are not returned,
+//         use PrepareReturning to load them.
+//
+// **Returns**
+// - `PreparedStatement`: Statement to execute
+func (statement *InsertStatement) Prepare() *PreparedStatement {
+	var command strings.Builder
+
+	err := statement.writeCommand(&command, nil)
+
+	loadresult := false
+	if statement.returnid && err == nil {
+		loadresult, err = statement.connectioninfo.ReturnIdentity(statement.model.Identity(), &command)
 	}
 
 	return &PreparedStatement{
-		command:    command.String(),
-		connection: statement.connection,
-		loadresult: statement.returnid && len(postquery) == 0,
-		postquery:  postquery}
+		command:      command.String(),
+		connection:   statement.connection,
+		loadresult:   loadresult,
+		lastinsertid: statement.returnid && !loadresult,
+		err:          err}
+}
+
+// PrepareReturning prepares the insert statement for execution returning fields of the inserted rows.
+//                  If no fields were specified using Returning all columns of the model are returned.
+//
+// **Returns**
+//   - *PreparedLoadStatement: statement to execute, rows can get mapped to entities using ExecuteEntity
+func (statement *InsertStatement) PrepareReturning() *PreparedLoadStatement {
+	var command strings.Builder
+
+	err := statement.writeCommand(&command, returningFields(statement.model, statement.returning))
+
+	return &PreparedLoadStatement{
+		command:        command.String(),
+		err:            err,
+		connection:     statement.connection,
+		connectioninfo: statement.connectioninfo,
+		model:          statement.model}
+}
+
+// PrepareBatch prepares the insert statement for execution of multiple rows at once. Values and ReturnID are ignored,
+//              every row has to provide a value for every column specified using Columns.
+//
+// **Returns**
+//   - *PreparedBatchStatement: statement to execute
+func (statement *InsertStatement) PrepareBatch() *PreparedBatchStatement {
+	var header strings.Builder
+	statement.writeHeader(&header)
+
+	var err error
+	batchsize := statement.connectioninfo.MaxBatchRows(len(statement.fields))
+	if batchsize <= 0 {
+		err = fmt.Errorf("Inserting %d columns per row exceeds the number of parameters supported by the database", len(statement.fields))
+	}
+
+	return &PreparedBatchStatement{
+		header:         header.String(),
+		columns:        len(statement.fields),
+		batchsize:      batchsize,
+		connection:     statement.connection,
+		connectioninfo: statement.connectioninfo,
+		err:            err}
 }
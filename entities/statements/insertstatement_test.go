@@ -2,7 +2,10 @@ package statements
 
 import (
 	"database/sql"
+	"fmt"
+	"path/filepath"
 	"reflect"
+	"sync"
 	"testing"
 
 	"github.com/verticalgmbh/database-go/xpr"
@@ -153,3 +156,170 @@ func TestReturnId(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, int64(2), id)
 }
+
+func TestReturnIdConcurrent(t *testing.T) {
+	info := &connection.SqliteInfo{}
+	database, _ := sql.Open("sqlite3", filepath.Join(t.TempDir(), "returnid.db3")+"?_busy_timeout=5000")
+	defer database.Close()
+
+	_, err := database.Exec("CREATE TABLE insertmodel (id INTEGER PRIMARY KEY AUTOINCREMENT, something string, someint int, somefloat real)")
+	require.NoError(t, err)
+
+	model := models.CreateModel(reflect.TypeOf(InsertModel{}))
+	operation := NewInsertStatement(model, database, info).Columns("Something", "SomeInt", "SomeFloat").ReturnID().Prepare()
+
+	// every insert has to return the id of its own row even if other connections of the pool insert rows concurrently
+	var group sync.WaitGroup
+	ids := make([][]int64, 4)
+	errs := make([]error, 4)
+	for worker := range ids {
+		group.Add(1)
+		go func(worker int) {
+			defer group.Done()
+			for index := 0; index < 25; index++ {
+				id, err := operation.Execute(fmt.Sprintf("worker%d", worker), index, 0.0)
+				if err != nil {
+					errs[worker] = err
+					return
+				}
+				ids[worker] = append(ids[worker], id)
+			}
+		}(worker)
+	}
+	group.Wait()
+
+	for worker, workerids := range ids {
+		require.NoError(t, errs[worker])
+		for index, id := range workerids {
+			var something string
+			var someint int
+			require.NoError(t, database.QueryRow("SELECT something, someint FROM insertmodel WHERE id = ?", id).Scan(&something, &someint))
+			require.Equal(t, fmt.Sprintf("worker%d", worker), something)
+			require.Equal(t, index, someint)
+		}
+	}
+}
+
+func TestInsertReturning(t *testing.T) {
+	database, _ := sql.Open("sqlite3", ":memory:")
+	defer database.Close()
+
+	database.Exec("CREATE TABLE insertmodel (something string, someint int, somefloat real DEFAULT 0.5)")
+
+	model := models.CreateModel(reflect.TypeOf(InsertModel{}))
+
+	statement := NewInsertStatement(model, database, &connection.SqliteInfo{})
+	statement.Columns("Something", "SomeInt")
+	statement.Returning()
+
+	operation := statement.PrepareReturning()
+	require.NoError(t, operation.Err())
+	require.Equal(t, "INSERT INTO insertmodel ([something],[someint]) VALUES(?,?) RETURNING [something],[someint],[somefloat]", operation.Command())
+
+	entities, err := operation.ExecuteEntity("Tralla", 42)
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{&InsertModel{Something: "Tralla", SomeInt: 42, SomeFloat: 0.5}}, entities)
+
+	update := NewUpdateStatement(model, database, &connection.SqliteInfo{})
+	update.Set(xpr.Assign(xpr.Field(model, "SomeInt"), xpr.Add(xpr.Field(model, "SomeInt"), 1))).Returning(xpr.Field(model, "SomeInt"))
+
+	result, err := update.PrepareReturning().ExecuteScalar()
+	require.NoError(t, err)
+	require.Equal(t, int64(43), result)
+
+	deleted, err := NewDeleteStatement(model, database, &connection.SqliteInfo{}).Returning().PrepareReturning().ExecuteEntity()
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{&InsertModel{Something: "Tralla", SomeInt: 43, SomeFloat: 0.5}}, deleted)
+}
+
+func TestBatchInsert(t *testing.T) {
+	database, _ := sql.Open("sqlite3", ":memory:")
+	defer database.Close()
+
+	database.Exec("CREATE TABLE insertmodel (something string, someint int, somefloat real)")
+
+	model := models.CreateModel(reflect.TypeOf(InsertModel{}))
+	statement := NewInsertStatement(model, database, &connection.SqliteInfo{})
+	statement.Columns("Something", "SomeInt", "SomeFloat")
+
+	operation := statement.PrepareBatch()
+	require.Equal(t, 333, operation.BatchSize())
+	require.Equal(t, "INSERT INTO insertmodel ([something],[someint],[somefloat]) VALUES(?,?,?),(?,?,?)", operation.Command(2))
+
+	var rows [][]interface{}
+	for index := 0; index < 1000; index++ {
+		rows = append(rows, []interface{}{"Tralla", index, 0.5})
+	}
+
+	count, err := operation.Execute(rows...)
+	require.NoError(t, err)
+	require.Equal(t, int64(1000), count)
+
+	result, err := NewLoadStatement(database, &connection.SqliteInfo{}).Table(model.Table).Fields(xpr.Max(xpr.Field(model, "SomeInt"))).Prepare().ExecuteScalar()
+	require.NoError(t, err)
+	require.Equal(t, int64(999), result)
+}
+
+func TestBatchInsertInvalidRow(t *testing.T) {
+	database, _ := sql.Open("sqlite3", ":memory:")
+	defer database.Close()
+
+	database.Exec("CREATE TABLE insertmodel (something string, someint int, somefloat real)")
+
+	model := models.CreateModel(reflect.TypeOf(InsertModel{}))
+	statement := NewInsertStatement(model, database, &connection.SqliteInfo{})
+	statement.Columns("Something", "SomeInt")
+
+	_, err := statement.PrepareBatch().Execute([]interface{}{"Tralla", 1}, []interface{}{"Trulla"})
+	require.Error(t, err)
+}
+
+func TestBatchInsertTooManyColumns(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(InsertModel{}))
+
+	fields := make([]string, 999)
+	for index := range fields {
+		fields[index] = "Something"
+	}
+
+	require.Equal(t, 1, NewInsertStatement(model, nil, &connection.SqliteInfo{}).Columns(fields...).PrepareBatch().BatchSize())
+
+	operation := NewInsertStatement(model, nil, &connection.SqliteInfo{}).Columns(append(fields, "SomeInt")...).PrepareBatch()
+	require.Equal(t, 0, operation.BatchSize())
+	require.Error(t, operation.Err())
+
+	_, err := operation.Execute(make([]interface{}, 1000))
+	require.Error(t, err)
+}
+
+func TestExecuteLockedRetryable(t *testing.T) {
+	info := &connection.SqliteInfo{}
+	filename := filepath.Join(t.TempDir(), "locked.db3")
+
+	database, _ := sql.Open("sqlite3", filename)
+	defer database.Close()
+	other, _ := sql.Open("sqlite3", filename+"?_busy_timeout=0")
+	defer other.Close()
+
+	_, err := database.Exec("CREATE TABLE insertmodel (something string, someint int, somefloat real)")
+	require.NoError(t, err)
+
+	transaction, err := database.Begin()
+	require.NoError(t, err)
+	defer transaction.Rollback()
+
+	model := models.CreateModel(reflect.TypeOf(InsertModel{}))
+	operation := NewInsertStatement(model, other, info).Columns("Something", "SomeInt", "SomeFloat").Prepare()
+
+	_, err = operation.ExecuteTransaction(transaction, "locking", 1, 0.0)
+	require.NoError(t, err)
+
+	// driver error has to remain available to detect errors by code
+	_, err = operation.Execute("locked", 2, 0.0)
+	require.Error(t, err)
+	require.True(t, info.IsRetryable(err))
+
+	_, err = NewInsertStatement(model, other, info).Columns("Something", "SomeInt", "SomeFloat").PrepareBatch().Execute([]interface{}{"locked", 3, 0.0})
+	require.Error(t, err)
+	require.True(t, info.IsRetryable(err))
+}
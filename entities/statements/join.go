@@ -1,17 +1,31 @@
 package statements
 
+import "github.com/verticalgmbh/database-go/xpr"
+
 // JoinType specified the type of join to apply
-type JoinType int8
+type JoinType = xpr.JoinType
 
 const (
 	// JoinTypeInner specifies an INNER JOIN operation
-	JoinTypeInner JoinType = iota
+	JoinTypeInner = xpr.JoinInner
+
+	// JoinTypeLeft specifies a LEFT OUTER JOIN operation
+	JoinTypeLeft = xpr.JoinLeft
+
+	// JoinTypeRight specifies a RIGHT OUTER JOIN operation
+	JoinTypeRight = xpr.JoinRight
+
+	// JoinTypeFull specifies a FULL OUTER JOIN operation
+	JoinTypeFull = xpr.JoinFull
+
+	// JoinTypeCross specifies a CROSS JOIN operation
+	JoinTypeCross = xpr.JoinCross
 )
 
 // Join applies a join to a load operation
 type join struct {
 	jointype  JoinType
-	table     string
+	source    interface{}
 	alias     string
 	predicate interface{}
 }
@@ -2,6 +2,7 @@ package statements
 
 import (
 	"database/sql"
+	"errors"
 	"reflect"
 	"testing"
 
@@ -70,3 +71,143 @@ func TestJoin(t *testing.T) {
 	prepared := statement.Prepare()
 	require.Equal(t, "SELECT [something],[someint],[somefloat] FROM examplemodel AS t INNER JOIN differenttable AS dt ON dt.[key] = 8 WHERE t.[test] = 10", prepared.Command())
 }
+
+func createCursorDatabase(t *testing.T) (*sql.DB, *models.EntityModel) {
+	database, _ := sql.Open("sqlite3", ":memory:")
+	database.SetMaxOpenConns(1)
+
+	_, err := database.Exec("CREATE TABLE examplemodel (something string, someint int, somefloat real)")
+	require.NoError(t, err)
+	_, err = database.Exec("INSERT INTO examplemodel (something, someint, somefloat) VALUES ('hallo', 0, 0.5), ('hello', 2, 0.2), ('hillo', 1, 0.8)")
+	require.NoError(t, err)
+
+	return database, models.CreateModel(reflect.TypeOf(ExampleModel{}))
+}
+
+func TestCursor(t *testing.T) {
+	database, model := createCursorDatabase(t)
+	defer database.Close()
+
+	statement := NewLoadStatement(database, &connection.SqliteInfo{}).Model(model).OrderBy(xpr.Field(model, "SomeInt"))
+
+	cursor, err := statement.Prepare().ExecuteCursor()
+	require.NoError(t, err)
+	defer cursor.Close()
+
+	var entities []*ExampleModel
+	for cursor.Next() {
+		entities = append(entities, cursor.Entity().(*ExampleModel))
+	}
+
+	require.NoError(t, cursor.Err())
+	require.Nil(t, cursor.Entity())
+	require.Equal(t, []*ExampleModel{
+		{Something: "hallo", SomeInt: 0, SomeFloat: 0.5},
+		{Something: "hillo", SomeInt: 1, SomeFloat: 0.8},
+		{Something: "hello", SomeInt: 2, SomeFloat: 0.2}}, entities)
+}
+
+func TestForEach(t *testing.T) {
+	database, model := createCursorDatabase(t)
+	defer database.Close()
+
+	statement := NewLoadStatement(database, &connection.SqliteInfo{}).Model(model).Where(xpr.Grt(xpr.Field(model, "SomeInt"), xpr.Parameter())).Prepare()
+
+	var names []string
+	err := statement.ForEach(func(entity interface{}) error {
+		names = append(names, entity.(*ExampleModel).Something)
+		return nil
+	}, 0)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"hello", "hillo"}, names)
+
+	stop := errors.New("stop iteration")
+	calls := 0
+	err = statement.ForEach(func(entity interface{}) error {
+		calls++
+		return stop
+	}, 0)
+	require.Equal(t, stop, err)
+	require.Equal(t, 1, calls)
+}
+
+type ExtraModel struct {
+	Something string
+	Extra     map[string]interface{} `database:"extra"`
+}
+
+func TestMappingScanError(t *testing.T) {
+	database, model := createCursorDatabase(t)
+	defer database.Close()
+
+	_, err := database.Exec("INSERT INTO examplemodel (something, someint, somefloat) VALUES ('hullo', 'invalid', 1.3)")
+	require.NoError(t, err)
+
+	statement := NewLoadStatement(database, &connection.SqliteInfo{}).Model(model).OrderBy(xpr.Field(model, "Something"))
+
+	_, err = statement.Prepare().ExecuteEntity()
+
+	var mappingerror *MappingError
+	require.True(t, errors.As(err, &mappingerror))
+	require.Equal(t, 3, mappingerror.Row)
+	require.Equal(t, "someint", mappingerror.Column)
+}
+
+type failingScanner struct{}
+
+func (value *failingScanner) Scan(source interface{}) error {
+	return errors.New("value is not supported")
+}
+
+type ScannerModel struct {
+	Something string
+	SomeInt   failingScanner
+	SomeFloat float32
+}
+
+func TestMappingScannerError(t *testing.T) {
+	database, _ := createCursorDatabase(t)
+	defer database.Close()
+
+	model := models.CreateModel(reflect.TypeOf(ScannerModel{}))
+	model.Table = "examplemodel"
+
+	_, err := NewLoadStatement(database, &connection.SqliteInfo{}).Model(model).Prepare().ExecuteEntity()
+
+	var mappingerror *MappingError
+	require.True(t, errors.As(err, &mappingerror))
+	require.Equal(t, 0, mappingerror.Row)
+	require.Equal(t, "someint", mappingerror.Column)
+}
+
+func TestMappingUnmappedColumns(t *testing.T) {
+	database, model := createCursorDatabase(t)
+	defer database.Close()
+
+	extramodel := models.CreateModel(reflect.TypeOf(ExtraModel{}))
+	require.Equal(t, "Extra", extramodel.Extra())
+	require.Nil(t, extramodel.ColumnFromField("Extra"))
+
+	statement := NewLoadStatement(database, &connection.SqliteInfo{}).Table(model.Table).Fields(xpr.Field(model, "Something"), xpr.Field(model, "SomeInt"))
+	statement.Where(xpr.Equals(xpr.Field(model, "Something"), "hello"))
+
+	_, err := statement.Prepare().ExecuteMappedEntity(extramodel)
+	var mappingerror *MappingError
+	require.True(t, errors.As(err, &mappingerror))
+	require.Equal(t, -1, mappingerror.Row)
+	require.Equal(t, "someint", mappingerror.Column)
+
+	result, err := statement.Unmapped(UnmappedColumnsIgnore).Prepare().ExecuteMappedEntity(extramodel)
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{&ExtraModel{Something: "hello"}}, result)
+
+	result, err = statement.Unmapped(UnmappedColumnsCollect).Prepare().ExecuteMappedEntity(extramodel)
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{&ExtraModel{Something: "hello", Extra: map[string]interface{}{"someint": int64(2)}}}, result)
+
+	_, err = statement.Unmapped(UnmappedColumnsCollect).Prepare().ExecuteMappedEntity(model)
+	require.Error(t, err)
+
+	_, err = statement.Prepare().ExecuteEntity()
+	require.Error(t, err)
+}
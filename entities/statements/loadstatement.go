@@ -1,7 +1,8 @@
 package statements
 
 import (
-	"database/sql"
+	"errors"
+	"fmt"
 	"log"
 	"strings"
 
@@ -10,22 +11,30 @@ import (
 	"github.com/verticalgmbh/database-go/connection"
 	"github.com/verticalgmbh/database-go/entities/models"
 	"github.com/verticalgmbh/database-go/entities/walkers"
+	"github.com/verticalgmbh/database-go/interfaces"
 )
 
 // LoadStatement statement used to load data from the database
 type LoadStatement struct {
-	connection     *sql.DB
+	connection     interfaces.IExecutor
 	connectioninfo connection.IConnectionInfo
 	from           interface{}
-	alias          string // alias to use for selection source
+	alias          string          // alias to use for selection source
+	distinct       bool            // determines whether duplicate rows are removed from the result
+	unmapped       UnmappedColumns // determines how result columns not mapped to a field are handled
 
 	model   *models.EntityModel // model to base select on
 	fields  []interface{}
 	groupby []interface{}
+	having  interface{} // predicate to filter groups
+	orderby []interface{}
 	where   interface{}
+	limit   interface{} // maximum number of rows to load
+	offset  interface{} // number of rows to skip
 
-	joins []*join
-	union *union
+	with          []*commontable
+	joins         []*join
+	setoperations []*setoperation
 }
 
 // NewLoadStatement creates a new statement used to load data from the database
@@ -37,7 +46,7 @@ type LoadStatement struct {
 //
 // **Returns**
 //   - LoadStatement: statement to use to prepare operation
-func NewLoadStatement(connection *sql.DB, connectioninfo connection.IConnectionInfo) *LoadStatement {
+func NewLoadStatement(connection interfaces.IExecutor, connectioninfo connection.IConnectionInfo) *LoadStatement {
 	return &LoadStatement{
 		connection:     connection,
 		connectioninfo: connectioninfo}
@@ -68,7 +77,8 @@ func (statement *LoadStatement) Model(model *models.EntityModel) *LoadStatement
 // From - specifies a data set to load results from
 //
 // **Parameters**
-//   - from: data to select result from
+//   - from: data to select result from. Either a table name, an xpr.Table, an entity model or a subquery
+//           specified as *LoadStatement or *PreparedLoadStatement
 //
 // **Returns**
 //   - *LoadStatement: this statement for fluent behavior
@@ -96,6 +106,28 @@ func (statement *LoadStatement) Alias(alias string) *LoadStatement {
 	return statement
 }
 
+// Distinct removes duplicate rows from the result
+//
+// **Returns**
+//   - *LoadStatement: this statement for fluent behavior
+func (statement *LoadStatement) Distinct() *LoadStatement {
+	statement.distinct = true
+	return statement
+}
+
+// Unmapped specifies how result columns are handled which are not mapped to a field of the loaded entities.
+//          By default mapping fails with a MappingError.
+//
+// **Parameters**
+//   - unmapped: handling of unmapped result columns
+//
+// **Returns**
+//   - *LoadStatement: this statement for fluent behavior
+func (statement *LoadStatement) Unmapped(unmapped UnmappedColumns) *LoadStatement {
+	statement.unmapped = unmapped
+	return statement
+}
+
 // Where set predicate for data to match
 //
 // **Parameters**
@@ -150,29 +182,334 @@ func (statement *LoadStatement) GroupBy(fields ...interface{}) *LoadStatement {
 	return statement
 }
 
-// Join adds a join operation to apply to the load statement
-func (statement *LoadStatement) Join(jointype JoinType, table string, predicate interface{}, alias string) *LoadStatement {
+// Having set predicate for groups to match. Only applies when used together with GroupBy
+//
+// **Parameters**
+//   - predicate: predicate expression filtering groups, usually based on aggregate functions like xpr.Count()
+//
+// **Returns**
+//   - *LoadStatement: this statement for fluent behavior
+func (statement *LoadStatement) Having(predicate interface{}) *LoadStatement {
+	statement.having = predicate
+	return statement
+}
+
+// OrderBy set criterias for result ordering. Ordering is applied to the whole result
+//         including result sets combined using Union, Intersect or Except
+//
+// **Parameters**
+//   - fields: expressions to order by, use xpr.Asc and xpr.Desc to specify the direction
+//
+// **Returns**
+//   - *LoadStatement: this statement for fluent behavior
+func (statement *LoadStatement) OrderBy(fields ...interface{}) *LoadStatement {
+	statement.orderby = fields
+	return statement
+}
+
+// Limit limits the number of rows to load
+//
+// **Parameters**
+//   - limit: maximum number of rows to load. Either a constant or an expression like xpr.Parameter()
+//
+// **Returns**
+//   - *LoadStatement: this statement for fluent behavior
+func (statement *LoadStatement) Limit(limit interface{}) *LoadStatement {
+	statement.limit = limit
+	return statement
+}
+
+// Offset specifies a number of rows to skip before rows are loaded
+//
+// When using parameters for limit and offset, the argument for the limit is expected before the argument
+// for the offset in every database.
+//
+// **Parameters**
+//   - offset: number of rows to skip. Either a constant or an expression like xpr.Parameter()
+//
+// **Returns**
+//   - *LoadStatement: this statement for fluent behavior
+func (statement *LoadStatement) Offset(offset interface{}) *LoadStatement {
+	statement.offset = offset
+	return statement
+}
+
+// With defines a common table expression which can be referenced using xpr.Table(name) as source in From or Join
+//
+// **Parameters**
+//   - name: name of common table expression
+//   - load: statement providing the rows of the common table expression
+//
+// **Returns**
+//   - *LoadStatement: this statement for fluent behavior
+func (statement *LoadStatement) With(name string, load *LoadStatement) *LoadStatement {
+	statement.with = append(statement.with, &commontable{
+		name:      name,
+		statement: load})
+	return statement
+}
+
+// WithRecursive defines a recursive common table expression which can be referenced using xpr.Table(name)
+//               as source in From or Join
+//
+// **Parameters**
+//   - name:      name of common table expression
+//   - anchor:    statement providing the initial rows of the common table expression
+//   - recursive: statement referencing the common table expression by name, providing rows for the next recursion.
+//                Rows are concatenated to the rows of the anchor using UNION ALL
+//
+// **Returns**
+//   - *LoadStatement: this statement for fluent behavior
+func (statement *LoadStatement) WithRecursive(name string, anchor *LoadStatement, recursive *LoadStatement) *LoadStatement {
+	statement.with = append(statement.with, &commontable{
+		name:      name,
+		statement: anchor,
+		recursive: recursive})
+	return statement
+}
+
+// Join adds a join operation to apply to the load statement. If the database does not support
+//      the join type the error is reported when the statement is prepared.
+//
+// **Parameters**
+//   - jointype:  type of join to apply
+//   - source:    source to join. Either a table name, an xpr.Table, an entity model or a subquery
+//                specified as *LoadStatement or *PreparedLoadStatement
+//   - predicate: predicate specifying matching rows, nil for JoinTypeCross
+//   - alias:     alias to use for joined source, required for subqueries
+//
+// **Returns**
+//   - *LoadStatement: this statement for fluent behavior
+func (statement *LoadStatement) Join(jointype JoinType, source interface{}, predicate interface{}, alias string) *LoadStatement {
 	statement.joins = append(statement.joins, &join{
 		jointype:  jointype,
-		table:     table,
+		source:    source,
 		predicate: predicate,
 		alias:     alias})
 	return statement
 }
 
-// Union - concatenates another result set to a result
-func (statement *LoadStatement) Union(load *PreparedLoadStatement, all bool) *LoadStatement {
-	statement.union = &union{
-		statement: load,
-		all:       all}
+// Union - concatenates another result set to a result. Set operations are applied in the order they are specified.
+//         Statements providing rows to combine can not specify OrderBy, Limit or Offset.
+//
+// **Parameters**
+//   - load: *LoadStatement or *PreparedLoadStatement providing rows to concatenate
+//   - all:  determines whether duplicate rows are kept
+//
+// **Returns**
+//   - *LoadStatement: this statement for fluent behavior
+func (statement *LoadStatement) Union(load interface{}, all bool) *LoadStatement {
+	if all {
+		return statement.combine(setoperatorUnionAll, load)
+	}
+	return statement.combine(setoperatorUnion, load)
+}
+
+// UnionAll - concatenates another result set to a result keeping duplicate rows
+//
+// **Parameters**
+//   - load: *LoadStatement or *PreparedLoadStatement providing rows to concatenate
+//
+// **Returns**
+//   - *LoadStatement: this statement for fluent behavior
+func (statement *LoadStatement) UnionAll(load interface{}) *LoadStatement {
+	return statement.combine(setoperatorUnionAll, load)
+}
+
+// Intersect - only keeps rows which are also contained in another result set
+//
+// **Parameters**
+//   - load: *LoadStatement or *PreparedLoadStatement providing rows to intersect with
+//
+// **Returns**
+//   - *LoadStatement: this statement for fluent behavior
+func (statement *LoadStatement) Intersect(load interface{}) *LoadStatement {
+	return statement.combine(setoperatorIntersect, load)
+}
+
+// Except - removes rows which are contained in another result set
+//
+// **Parameters**
+//   - load: *LoadStatement or *PreparedLoadStatement providing rows to remove
+//
+// **Returns**
+//   - *LoadStatement: this statement for fluent behavior
+func (statement *LoadStatement) Except(load interface{}) *LoadStatement {
+	return statement.combine(setoperatorExcept, load)
+}
+
+func (statement *LoadStatement) combine(operator setoperator, load interface{}) *LoadStatement {
+	switch load.(type) {
+	case *LoadStatement, *PreparedLoadStatement:
+	default:
+		log.Panicf("Set operations expect a *LoadStatement or *PreparedLoadStatement but got %T", load)
+	}
+
+	statement.setoperations = append(statement.setoperations, &setoperation{
+		operator:  operator,
+		statement: load})
 	return statement
 }
 
-func (statement *LoadStatement) buildCommand() string {
+// isSubquery determines whether a data source is a statement which has to be rendered as subquery
+func isSubquery(source interface{}) bool {
+	switch source.(type) {
+	case *LoadStatement, *PreparedLoadStatement:
+		return true
+	}
+	return false
+}
+
+// writeSource writes a data source of a FROM or JOIN clause
+func (statement *LoadStatement) writeSource(source interface{}, command *strings.Builder, parameters *connection.Parameters, sqlwalker *walkers.SqlWalker) error {
+	switch v := source.(type) {
+	case string:
+		command.WriteString(v)
+	case *models.EntityModel:
+		command.WriteString(v.Table)
+	case *LoadStatement:
+		command.WriteRune('(')
+		err := v.writeCommand(command, parameters)
+		if err != nil {
+			return err
+		}
+		command.WriteRune(')')
+	case *PreparedLoadStatement:
+		command.WriteRune('(')
+		err := v.WriteCommand(command, parameters)
+		if err != nil {
+			return err
+		}
+		command.WriteRune(')')
+	default:
+		err := sqlwalker.Visit(source)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// wrappedSetOperations determines before which set operations the rows combined so far are wrapped into a derived table.
+// INTERSECT binds tighter than UNION and EXCEPT in most databases, so rows combined using other operators are wrapped
+// before an INTERSECT to apply set operations in the order they are specified.
+func (statement *LoadStatement) wrappedSetOperations() []bool {
+	wrapped := make([]bool, len(statement.setoperations))
+	mixed := false
+	for index, operation := range statement.setoperations {
+		if operation.operator == setoperatorIntersect {
+			wrapped[index] = mixed
+			mixed = false
+		} else {
+			mixed = true
+		}
+	}
+	return wrapped
+}
+
+// writeSetOperand writes a statement providing rows to combine using a set operation. Statements combining rows
+// themselves are written as derived table, so their set operations are applied before the operation.
+func writeSetOperand(operand interface{}, index int, command *strings.Builder, parameters *connection.Parameters) error {
+	var load *LoadStatement
+	switch v := operand.(type) {
+	case *LoadStatement:
+		load = v
+	case *PreparedLoadStatement:
+		if v.err != nil || v.load == nil {
+			// reports why the statement can not be written as part of the command
+			return v.WriteCommand(command, parameters)
+		}
+		load = v.load
+	}
+
+	if len(load.orderby) > 0 || load.limit != nil || load.offset != nil {
+		return errors.New("Statements combined using set operations can not specify ORDER BY, LIMIT or OFFSET")
+	}
+
+	if len(load.setoperations) == 0 && len(load.with) == 0 {
+		return load.writeCommand(command, parameters)
+	}
+
+	command.WriteString("SELECT * FROM (")
+	err := load.writeCommand(command, parameters)
+	if err != nil {
+		return err
+	}
+	command.WriteString(fmt.Sprintf(") AS operand%d", index))
+	return nil
+}
+
+func (statement *LoadStatement) buildCommand() (string, error) {
 	var command strings.Builder
-	sqlwalker := walkers.NewSqlWalker(statement.connectioninfo, &command)
+	err := statement.writeCommand(&command, connection.NewParameters())
+	if err != nil {
+		return "", err
+	}
+
+	return command.String(), nil
+}
+
+// writeWith writes the common table expressions of the statement
+func (statement *LoadStatement) writeWith(command *strings.Builder, parameters *connection.Parameters) error {
+	recursive := false
+	for _, table := range statement.with {
+		if table.recursive != nil {
+			recursive = true
+		}
+	}
+
+	statement.connectioninfo.EvaluateWith(recursive, command)
+	for index, table := range statement.with {
+		if index > 0 {
+			command.WriteRune(',')
+		}
+
+		command.WriteString(table.name)
+		command.WriteString(" AS (")
+		err := table.statement.writeCommand(command, parameters)
+		if err != nil {
+			return err
+		}
+
+		if table.recursive != nil {
+			command.WriteString(" UNION ALL ")
+			err = table.recursive.writeCommand(command, parameters)
+			if err != nil {
+				return err
+			}
+		}
+		command.WriteRune(')')
+	}
+
+	command.WriteRune(' ')
+	return nil
+}
+
+// writeCommand writes the command of the statement to an existing command builder. Writing subqueries to the same
+//              builder using the same parameters keeps parameter numbering consistent for databases using indexed parameters.
+func (statement *LoadStatement) writeCommand(command *strings.Builder, parameters *connection.Parameters) error {
+	sqlwalker := walkers.NewSqlWalker(statement.connectioninfo, command, parameters)
+
+	if len(statement.with) > 0 {
+		err := statement.writeWith(command, parameters)
+		if err != nil {
+			return err
+		}
+	}
+
+	wrapped := statement.wrappedSetOperations()
+	for _, wrap := range wrapped {
+		if wrap {
+			command.WriteString("SELECT * FROM (")
+		}
+	}
 
 	command.WriteString("SELECT ")
+	if statement.distinct {
+		command.WriteString("DISTINCT ")
+	}
+
 	if statement.model != nil {
 		for index, column := range statement.model.Columns() {
 			if index > 0 {
@@ -187,13 +524,19 @@ func (statement *LoadStatement) buildCommand() string {
 				command.WriteRune(',')
 			}
 
-			sqlwalker.Visit(field)
+			err := sqlwalker.Visit(field)
+			if err != nil {
+				return err
+			}
 		}
 	}
 
 	if statement.from != nil {
 		command.WriteString(" FROM ")
-		sqlwalker.Visit(statement.from)
+		err := statement.writeSource(statement.from, command, parameters, sqlwalker)
+		if err != nil {
+			return err
+		}
 	}
 
 	if len(statement.alias) > 0 {
@@ -203,29 +546,47 @@ func (statement *LoadStatement) buildCommand() string {
 
 	if len(statement.joins) > 0 {
 		for _, joinoperation := range statement.joins {
-			switch joinoperation.jointype {
-			case JoinTypeInner:
-				command.WriteString(" INNER JOIN ")
-			default:
-				log.Panicf("Invalid join type %v", joinoperation.jointype)
+			if isSubquery(joinoperation.source) && len(joinoperation.alias) == 0 {
+				return errors.New("A subquery used as join source requires an alias")
+			}
+
+			command.WriteRune(' ')
+			err := statement.connectioninfo.EvaluateJoin(joinoperation.jointype, command)
+			if err != nil {
+				return err
+			}
+
+			command.WriteRune(' ')
+			err = statement.writeSource(joinoperation.source, command, parameters, sqlwalker)
+			if err != nil {
+				return err
 			}
 
-			command.WriteString(joinoperation.table)
 			if len(joinoperation.alias) > 0 {
 				command.WriteString(" AS ")
 				command.WriteString(joinoperation.alias)
 			}
 
 			if joinoperation.predicate != nil {
+				if joinoperation.jointype == JoinTypeCross {
+					return errors.New("A cross join can not be combined with a join predicate")
+				}
+
 				command.WriteString(" ON ")
-				sqlwalker.Visit(joinoperation.predicate)
+				err = sqlwalker.Visit(joinoperation.predicate)
+				if err != nil {
+					return err
+				}
 			}
 		}
 	}
 
 	if statement.where != nil {
 		command.WriteString(" WHERE ")
-		sqlwalker.Visit(statement.where)
+		err := sqlwalker.Visit(statement.where)
+		if err != nil {
+			return err
+		}
 	}
 
 	if statement.groupby != nil {
@@ -235,30 +596,116 @@ func (statement *LoadStatement) buildCommand() string {
 				command.WriteRune(',')
 			}
 
-			sqlwalker.Visit(field)
+			err := sqlwalker.Visit(field)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if statement.having != nil {
+		command.WriteString(" HAVING ")
+		err := sqlwalker.Visit(statement.having)
+		if err != nil {
+			return err
+		}
+	}
+
+	for index, operation := range statement.setoperations {
+		if wrapped[index] {
+			command.WriteString(fmt.Sprintf(") AS combined%d", index))
+		}
+
+		switch operation.operator {
+		case setoperatorUnion:
+			command.WriteString(" UNION ")
+		case setoperatorUnionAll:
+			command.WriteString(" UNION ALL ")
+		case setoperatorIntersect:
+			command.WriteString(" INTERSECT ")
+		case setoperatorExcept:
+			command.WriteString(" EXCEPT ")
+		}
+
+		err := writeSetOperand(operation.statement, index, command, parameters)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(statement.orderby) > 0 {
+		command.WriteString(" ORDER BY ")
+		for index, field := range statement.orderby {
+			if index > 0 {
+				command.WriteRune(',')
+			}
+
+			err := sqlwalker.Visit(field)
+			if err != nil {
+				return err
+			}
 		}
 	}
 
-	if statement.union != nil {
-		command.WriteString(" UNION ")
-		if statement.union.all {
-			command.WriteString("ALL ")
+	if statement.limit != nil || statement.offset != nil {
+		return statement.writeLimit(command, parameters)
+	}
+
+	return nil
+}
+
+// evaluatedExpression sql text of an expression which was already evaluated
+type evaluatedExpression string
+
+// writeLimit writes limit and offset of the statement. Limit and offset are evaluated in this order before
+// the database specific representation is written, so parameters are bound in the same order in every
+// database even if the database expects the offset to be specified first.
+func (statement *LoadStatement) writeLimit(command *strings.Builder, parameters *connection.Parameters) error {
+	evaluate := func(expression interface{}) (interface{}, error) {
+		if expression == nil {
+			return nil, nil
 		}
 
-		command.WriteString(statement.union.statement.Command())
+		var text strings.Builder
+		err := walkers.NewSqlWalker(statement.connectioninfo, &text, parameters).Visit(expression)
+		if err != nil {
+			return nil, err
+		}
+		return evaluatedExpression(text.String()), nil
 	}
 
-	return command.String()
+	limit, err := evaluate(statement.limit)
+	if err != nil {
+		return err
+	}
+
+	offset, err := evaluate(statement.offset)
+	if err != nil {
+		return err
+	}
+
+	return statement.connectioninfo.EvaluateLimit(limit, offset, len(statement.orderby) > 0, command, func(expression interface{}) error {
+		command.WriteString(string(expression.(evaluatedExpression)))
+		return nil
+	})
 }
 
-// Prepare prepares the load statement for execution
+// Prepare prepares the load statement for execution. If the statement can not be represented
+//         in the database the error is available using Err and returned when executing the statement.
 //
 // **Returns**
 //   - PreparedLoadStatement: statement to be used to load data
 func (statement *LoadStatement) Prepare() *PreparedLoadStatement {
+	command, err := statement.buildCommand()
+
+	// keep the statement as it is now, so the prepared statement is not changed by later modifications
+	load := *statement
 	return &PreparedLoadStatement{
-		command:        statement.buildCommand(),
+		command:        command,
+		err:            err,
 		connection:     statement.connection,
 		connectioninfo: statement.connectioninfo,
-		model:          statement.model}
+		model:          statement.model,
+		unmapped:       statement.unmapped,
+		load:           &load}
 }
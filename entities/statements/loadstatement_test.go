@@ -19,6 +19,12 @@ type LoadModel struct {
 	SomeFloat float32
 }
 
+type LoadStatistics struct {
+	SomeInt int
+	Maximum float32
+	Entries int64
+}
+
 func TestLoadDataCount(t *testing.T) {
 	database, _ := sql.Open("sqlite3", ":memory:")
 	defer database.Close()
@@ -70,3 +76,519 @@ func TestLoadGroupBy(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, 3, len(result))
 }
+
+func TestLoadHaving(t *testing.T) {
+	database, _ := sql.Open("sqlite3", ":memory:")
+	defer database.Close()
+
+	database.Exec("CREATE TABLE loadmodel (something string, someint int, somefloat real)")
+	database.Exec("INSERT INTO loadmodel (something, someint, somefloat) VALUES ('hallo', 0, 0.5)")
+	database.Exec("INSERT INTO loadmodel (something, someint, somefloat) VALUES ('hello', 2, 0.2)")
+	database.Exec("INSERT INTO loadmodel (something, someint, somefloat) VALUES ('hillo', 2, 0.8)")
+	database.Exec("INSERT INTO loadmodel (something, someint, somefloat) VALUES ('hullo', 5, 1.3)")
+	database.Exec("INSERT INTO loadmodel (something, someint, somefloat) VALUES ('hollo', 5, 1.1)")
+
+	model := models.CreateModel(reflect.TypeOf(LoadModel{}))
+
+	statement := NewLoadStatement(database, &connection.SqliteInfo{}).Table(model.Table)
+	statement.Fields(xpr.Field(model, "SomeInt"))
+	statement.GroupBy(xpr.Field(model, "SomeInt"))
+	statement.Having(xpr.And(xpr.Grt(xpr.Count(), 1), xpr.Les(xpr.Max(xpr.Field(model, "SomeFloat")), xpr.Parameter())))
+
+	operation := statement.Prepare()
+	require.Equal(t, "SELECT [someint] FROM loadmodel GROUP BY [someint] HAVING COUNT(*) > 1 AND MAX([somefloat]) < ?", operation.Command())
+
+	result, err := operation.ExecuteSet(1.0)
+
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{int64(2)}, result)
+}
+
+func TestLoadLeftJoin(t *testing.T) {
+	database, _ := sql.Open("sqlite3", ":memory:")
+	defer database.Close()
+
+	database.Exec("CREATE TABLE loadmodel (something string, someint int, somefloat real)")
+	database.Exec("CREATE TABLE other (someint int, name string)")
+	database.Exec("INSERT INTO loadmodel (something, someint, somefloat) VALUES ('hallo', 0, 0.5)")
+	database.Exec("INSERT INTO loadmodel (something, someint, somefloat) VALUES ('hello', 2, 0.2)")
+	database.Exec("INSERT INTO other (someint, name) VALUES (2, 'two')")
+
+	model := models.CreateModel(reflect.TypeOf(LoadModel{}))
+
+	statement := NewLoadStatement(database, &connection.SqliteInfo{}).Table(model.Table).Alias("l")
+	statement.Fields(xpr.Coalesce(xpr.AliasColumn("o", "name"), "none"))
+	statement.Join(JoinTypeLeft, "other", xpr.Equals(xpr.AliasColumn("o", "someint"), xpr.AliasField("l", model, "SomeInt")), "o")
+	statement.OrderBy(xpr.AliasField("l", model, "SomeInt"))
+
+	operation := statement.Prepare()
+	require.NoError(t, operation.Err())
+	require.Equal(t, "SELECT COALESCE(o.[name],'none') FROM loadmodel AS l LEFT OUTER JOIN other AS o ON o.[someint] = l.[someint] ORDER BY l.[someint]", operation.Command())
+
+	result, err := operation.ExecuteSet()
+
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{"none", "two"}, result)
+}
+
+func TestLoadCrossJoin(t *testing.T) {
+	database, _ := sql.Open("sqlite3", ":memory:")
+	defer database.Close()
+
+	database.Exec("CREATE TABLE loadmodel (something string, someint int, somefloat real)")
+	database.Exec("CREATE TABLE other (someint int, name string)")
+	database.Exec("INSERT INTO loadmodel (something, someint, somefloat) VALUES ('hallo', 0, 0.5)")
+	database.Exec("INSERT INTO loadmodel (something, someint, somefloat) VALUES ('hello', 2, 0.2)")
+	database.Exec("INSERT INTO other (someint, name) VALUES (1, 'one')")
+	database.Exec("INSERT INTO other (someint, name) VALUES (2, 'two')")
+
+	model := models.CreateModel(reflect.TypeOf(LoadModel{}))
+
+	statement := NewLoadStatement(database, &connection.SqliteInfo{}).Table(model.Table).Alias("l")
+	statement.Fields(xpr.Count())
+	statement.Join(JoinTypeCross, "other", nil, "o")
+
+	result, err := statement.Prepare().ExecuteScalar()
+
+	require.NoError(t, err)
+	require.Equal(t, int64(4), result)
+}
+
+func TestLoadOuterJoins(t *testing.T) {
+	database, _ := sql.Open("sqlite3", ":memory:")
+	defer database.Close()
+
+	database.Exec("CREATE TABLE loadmodel (something string, someint int, somefloat real)")
+	database.Exec("CREATE TABLE other (someint int, name string)")
+	database.Exec("INSERT INTO loadmodel (something, someint, somefloat) VALUES ('hallo', 0, 0.5)")
+	database.Exec("INSERT INTO loadmodel (something, someint, somefloat) VALUES ('hello', 2, 0.2)")
+	database.Exec("INSERT INTO other (someint, name) VALUES (1, 'one')")
+	database.Exec("INSERT INTO other (someint, name) VALUES (2, 'two')")
+
+	info := connection.NewSqliteInfo()
+	require.NoError(t, info.LoadVersion(database))
+
+	model := models.CreateModel(reflect.TypeOf(LoadModel{}))
+
+	// rows without a match in loadmodel are identified by the name of the row in other
+	expected := map[JoinType][]interface{}{
+		JoinTypeLeft:  {"hallo", "hello"},
+		JoinTypeRight: {"hello", "one"},
+		JoinTypeFull:  {"hallo", "hello", "one"}}
+
+	for jointype, rows := range expected {
+		statement := NewLoadStatement(database, info).Table(model.Table).Alias("l")
+		statement.Fields(xpr.Coalesce(xpr.AliasField("l", model, "Something"), xpr.AliasColumn("o", "name")))
+		statement.Join(jointype, "other", xpr.Equals(xpr.AliasColumn("o", "someint"), xpr.AliasField("l", model, "SomeInt")), "o")
+		statement.OrderBy(xpr.Coalesce(xpr.AliasField("l", model, "Something"), xpr.AliasColumn("o", "name")))
+
+		operation := statement.Prepare()
+		require.NoError(t, operation.Err())
+
+		result, err := operation.ExecuteSet()
+		require.NoError(t, err)
+		require.Equal(t, rows, result)
+	}
+}
+
+func TestLoadUnsupportedJoin(t *testing.T) {
+	database, _ := sql.Open("sqlite3", ":memory:")
+	defer database.Close()
+
+	model := models.CreateModel(reflect.TypeOf(LoadModel{}))
+
+	info := connection.NewSqliteInfo()
+	require.NoError(t, info.SetVersion("3.38.5"))
+
+	statement := NewLoadStatement(database, info).Table(model.Table).Alias("l")
+	statement.Fields(xpr.AliasField("l", model, "Something"))
+	statement.Join(JoinTypeFull, "other", xpr.Equals(xpr.AliasColumn("o", "someint"), xpr.AliasField("l", model, "SomeInt")), "o")
+
+	operation := statement.Prepare()
+	require.Error(t, operation.Err())
+
+	_, err := operation.ExecuteSet()
+	require.Error(t, err)
+}
+
+func TestLoadJoinSubquery(t *testing.T) {
+	database, _ := sql.Open("sqlite3", ":memory:")
+	defer database.Close()
+
+	database.Exec("CREATE TABLE loadmodel (something string, someint int, somefloat real)")
+	database.Exec("INSERT INTO loadmodel (something, someint, somefloat) VALUES ('hallo', 0, 0.5)")
+	database.Exec("INSERT INTO loadmodel (something, someint, somefloat) VALUES ('hello', 2, 0.2)")
+	database.Exec("INSERT INTO loadmodel (something, someint, somefloat) VALUES ('hillo', 2, 0.8)")
+
+	model := models.CreateModel(reflect.TypeOf(LoadModel{}))
+
+	groups := NewLoadStatement(database, &connection.SqliteInfo{}).Table(model.Table)
+	groups.Fields(xpr.Field(model, "SomeInt"))
+	groups.Where(xpr.Grt(xpr.Field(model, "SomeFloat"), xpr.Parameter()))
+	groups.GroupBy(xpr.Field(model, "SomeInt"))
+
+	statement := NewLoadStatement(database, &connection.SqliteInfo{}).Table(model.Table).Alias("l")
+	statement.Fields(xpr.AliasField("l", model, "Something"))
+	statement.Join(JoinTypeInner, groups, xpr.Equals(xpr.AliasColumn("g", "someint"), xpr.AliasField("l", model, "SomeInt")), "g")
+	statement.OrderBy(xpr.AliasField("l", model, "Something"))
+
+	operation := statement.Prepare()
+	require.NoError(t, operation.Err())
+	require.Equal(t, "SELECT l.[something] FROM loadmodel AS l INNER JOIN (SELECT [someint] FROM loadmodel WHERE [somefloat] > ? GROUP BY [someint]) AS g ON g.[someint] = l.[someint] ORDER BY l.[something]", operation.Command())
+
+	result, err := operation.ExecuteSet(0.6)
+
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{"hello", "hillo"}, result)
+}
+
+func TestLoadJoinModel(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(LoadModel{}))
+
+	statement := NewLoadStatement(nil, &connection.SqliteInfo{}).Table("other").Alias("o")
+	statement.Fields(xpr.AliasField("l", model, "Something"))
+	statement.Join(JoinTypeInner, model, xpr.Equals(xpr.AliasField("l", model, "SomeInt"), xpr.AliasColumn("o", "someint")), "l")
+
+	require.Equal(t, "SELECT l.[something] FROM other AS o INNER JOIN loadmodel AS l ON l.[someint] = o.[someint]", statement.Prepare().Command())
+}
+
+func TestLoadJoinSubqueryWithoutAlias(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(LoadModel{}))
+
+	subquery := NewLoadStatement(nil, &connection.SqliteInfo{}).Model(model)
+
+	statement := NewLoadStatement(nil, &connection.SqliteInfo{}).Table("other")
+	statement.Fields(xpr.Column("name"))
+	statement.Join(JoinTypeCross, subquery.Prepare(), nil, "")
+
+	require.Error(t, statement.Prepare().Err())
+}
+
+func TestLoadWith(t *testing.T) {
+	database, _ := sql.Open("sqlite3", ":memory:")
+	defer database.Close()
+
+	database.Exec("CREATE TABLE loadmodel (something string, someint int, somefloat real)")
+	database.Exec("INSERT INTO loadmodel (something, someint, somefloat) VALUES ('hallo', 0, 0.5)")
+	database.Exec("INSERT INTO loadmodel (something, someint, somefloat) VALUES ('hello', 2, 0.2)")
+	database.Exec("INSERT INTO loadmodel (something, someint, somefloat) VALUES ('hillo', 2, 0.8)")
+
+	model := models.CreateModel(reflect.TypeOf(LoadModel{}))
+
+	filtered := NewLoadStatement(database, &connection.SqliteInfo{}).Table(model.Table)
+	filtered.Fields(xpr.Field(model, "Something"), xpr.Field(model, "SomeInt")).Where(xpr.Equals(xpr.Field(model, "SomeInt"), xpr.Parameter()))
+
+	statement := NewLoadStatement(database, &connection.SqliteInfo{}).With("filtered", filtered).Table("filtered")
+	statement.Fields(xpr.Count())
+
+	operation := statement.Prepare()
+	require.Equal(t, "WITH filtered AS (SELECT [something],[someint] FROM loadmodel WHERE [someint] = ?) SELECT COUNT(*) FROM filtered", operation.Command())
+
+	result, err := operation.ExecuteScalar(2)
+
+	require.NoError(t, err)
+	require.Equal(t, int64(2), result)
+}
+
+func TestLoadWithRecursive(t *testing.T) {
+	database, _ := sql.Open("sqlite3", ":memory:")
+	defer database.Close()
+
+	database.Exec("CREATE TABLE category (id int, parent int, name string)")
+	database.Exec("INSERT INTO category (id, parent, name) VALUES (1, NULL, 'root')")
+	database.Exec("INSERT INTO category (id, parent, name) VALUES (2, 1, 'child')")
+	database.Exec("INSERT INTO category (id, parent, name) VALUES (3, 2, 'grandchild')")
+	database.Exec("INSERT INTO category (id, parent, name) VALUES (4, NULL, 'other')")
+
+	anchor := NewLoadStatement(database, &connection.SqliteInfo{}).Table("category")
+	anchor.Fields(xpr.Column("id"), xpr.Column("name")).Where(xpr.Equals(xpr.Column("id"), xpr.Parameter()))
+
+	recursive := NewLoadStatement(database, &connection.SqliteInfo{}).Table("category").Alias("c")
+	recursive.Fields(xpr.AliasColumn("c", "id"), xpr.AliasColumn("c", "name"))
+	recursive.Join(JoinTypeInner, xpr.Table("tree"), xpr.Equals(xpr.AliasColumn("c", "parent"), xpr.AliasColumn("t", "id")), "t")
+
+	statement := NewLoadStatement(database, &connection.SqliteInfo{}).WithRecursive("tree", anchor, recursive).Table("tree")
+	statement.Fields(xpr.Column("name")).OrderBy(xpr.Column("id"))
+
+	operation := statement.Prepare()
+	require.Equal(t, "WITH RECURSIVE tree AS (SELECT [id],[name] FROM category WHERE [id] = ? UNION ALL SELECT c.[id],c.[name] FROM category AS c INNER JOIN tree AS t ON c.[parent] = t.[id]) SELECT [name] FROM tree ORDER BY [id]", operation.Command())
+
+	result, err := operation.ExecuteSet(1)
+
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{"root", "child", "grandchild"}, result)
+}
+
+func TestLoadSetOperations(t *testing.T) {
+	database, _ := sql.Open("sqlite3", ":memory:")
+	defer database.Close()
+
+	database.Exec("CREATE TABLE loadmodel (something string, someint int, somefloat real)")
+	database.Exec("INSERT INTO loadmodel (something, someint, somefloat) VALUES ('hallo', 0, 0.5)")
+	database.Exec("INSERT INTO loadmodel (something, someint, somefloat) VALUES ('hello', 2, 0.2)")
+	database.Exec("INSERT INTO loadmodel (something, someint, somefloat) VALUES ('hillo', 3, 0.8)")
+	database.Exec("INSERT INTO loadmodel (something, someint, somefloat) VALUES ('hullo', 5, 1.3)")
+
+	model := models.CreateModel(reflect.TypeOf(LoadModel{}))
+
+	load := func(predicate interface{}) *LoadStatement {
+		return NewLoadStatement(database, &connection.SqliteInfo{}).Table(model.Table).Fields(xpr.Field(model, "Something")).Where(predicate)
+	}
+
+	statement := load(xpr.Equals(xpr.Field(model, "SomeInt"), 0))
+	statement.Union(load(xpr.Equals(xpr.Field(model, "SomeInt"), 2)), false)
+	statement.UnionAll(load(xpr.Grt(xpr.Field(model, "SomeInt"), 2)).Prepare())
+	statement.Except(load(xpr.Equals(xpr.Field(model, "SomeInt"), xpr.Parameter())))
+	statement.Intersect(load(xpr.Les(xpr.Field(model, "SomeFloat"), 1.0)))
+	statement.OrderBy(xpr.Desc(xpr.Field(model, "Something"))).Limit(2)
+
+	operation := statement.Prepare()
+	require.Equal(t, "SELECT * FROM (SELECT [something] FROM loadmodel WHERE [someint] = 0 UNION SELECT [something] FROM loadmodel WHERE [someint] = 2 UNION ALL SELECT [something] FROM loadmodel WHERE [someint] > 2 EXCEPT SELECT [something] FROM loadmodel WHERE [someint] = ?) AS combined3 INTERSECT SELECT [something] FROM loadmodel WHERE [somefloat] < 1 ORDER BY [something] DESC LIMIT 2", operation.Command())
+
+	result, err := operation.ExecuteSet(2)
+
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{"hillo", "hallo"}, result)
+}
+
+func TestLoadDistinct(t *testing.T) {
+	database, _ := sql.Open("sqlite3", ":memory:")
+	defer database.Close()
+
+	database.Exec("CREATE TABLE loadmodel (something string, someint int, somefloat real)")
+	database.Exec("INSERT INTO loadmodel (something, someint, somefloat) VALUES ('hallo', 0, 0.5)")
+	database.Exec("INSERT INTO loadmodel (something, someint, somefloat) VALUES ('hello', 2, 0.2)")
+	database.Exec("INSERT INTO loadmodel (something, someint, somefloat) VALUES ('hillo', 2, 0.8)")
+
+	model := models.CreateModel(reflect.TypeOf(LoadModel{}))
+
+	statement := NewLoadStatement(database, &connection.SqliteInfo{}).Table(model.Table).Distinct()
+	statement.Fields(xpr.Field(model, "SomeInt")).OrderBy(xpr.Field(model, "SomeInt"))
+
+	operation := statement.Prepare()
+	require.Equal(t, "SELECT DISTINCT [someint] FROM loadmodel ORDER BY [someint]", operation.Command())
+
+	result, err := operation.ExecuteSet()
+
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{int64(0), int64(2)}, result)
+}
+
+func TestLoadCountFunctions(t *testing.T) {
+	database, _ := sql.Open("sqlite3", ":memory:")
+	defer database.Close()
+
+	database.Exec("CREATE TABLE loadmodel (something string, someint int, somefloat real)")
+	database.Exec("INSERT INTO loadmodel (something, someint, somefloat) VALUES ('hallo', 0, 0.5)")
+	database.Exec("INSERT INTO loadmodel (something, someint, somefloat) VALUES ('hello', 2, 0.2)")
+	database.Exec("INSERT INTO loadmodel (something, someint, somefloat) VALUES ('hillo', 2, 0.8)")
+	database.Exec("INSERT INTO loadmodel (something, someint, somefloat) VALUES ('hullo', NULL, 1.3)")
+
+	model := models.CreateModel(reflect.TypeOf(LoadModel{}))
+
+	statement := NewLoadStatement(database, &connection.SqliteInfo{}).Table(model.Table)
+	statement.Fields(xpr.Count(), xpr.CountOf(xpr.Field(model, "SomeInt")), xpr.CountDistinct(xpr.Field(model, "SomeInt")))
+
+	operation := statement.Prepare()
+	require.Equal(t, "SELECT COUNT(*),COUNT([someint]),COUNT(DISTINCT [someint]) FROM loadmodel", operation.Command())
+
+	rows, err := operation.Execute()
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var all, values, distinct int64
+	require.True(t, rows.Next())
+	require.NoError(t, rows.Scan(&all, &values, &distinct))
+	require.Equal(t, int64(4), all)
+	require.Equal(t, int64(3), values)
+	require.Equal(t, int64(2), distinct)
+}
+
+func TestLoadMappedAlias(t *testing.T) {
+	database, _ := sql.Open("sqlite3", ":memory:")
+	defer database.Close()
+
+	database.Exec("CREATE TABLE loadmodel (something string, someint int, somefloat real)")
+	database.Exec("INSERT INTO loadmodel (something, someint, somefloat) VALUES ('hallo', 0, 0.5)")
+	database.Exec("INSERT INTO loadmodel (something, someint, somefloat) VALUES ('hello', 2, 0.25)")
+	database.Exec("INSERT INTO loadmodel (something, someint, somefloat) VALUES ('hillo', 2, 0.75)")
+
+	model := models.CreateModel(reflect.TypeOf(LoadModel{}))
+	statistics := models.CreateModel(reflect.TypeOf(LoadStatistics{}))
+
+	statement := NewLoadStatement(database, &connection.SqliteInfo{}).Table(model.Table)
+	statement.Fields(xpr.Field(model, "SomeInt"), xpr.As(xpr.Max(xpr.Field(model, "SomeFloat")), "maximum"), xpr.As(xpr.Count(), "Entries"))
+	statement.GroupBy(xpr.Field(model, "SomeInt")).OrderBy(xpr.Field(model, "SomeInt"))
+
+	operation := statement.Prepare()
+	require.Equal(t, "SELECT [someint],MAX([somefloat]) AS [maximum],COUNT(*) AS [Entries] FROM loadmodel GROUP BY [someint] ORDER BY [someint]", operation.Command())
+
+	result, err := operation.ExecuteMappedEntity(statistics)
+
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{
+		&LoadStatistics{SomeInt: 0, Maximum: 0.5, Entries: 1},
+		&LoadStatistics{SomeInt: 2, Maximum: 0.75, Entries: 2}}, result)
+}
+
+func TestLoadLimitOffset(t *testing.T) {
+	database, _ := sql.Open("sqlite3", ":memory:")
+	defer database.Close()
+
+	database.Exec("CREATE TABLE loadmodel (something string, someint int, somefloat real)")
+	database.Exec("INSERT INTO loadmodel (something, someint, somefloat) VALUES ('hallo', 0, 0.5)")
+	database.Exec("INSERT INTO loadmodel (something, someint, somefloat) VALUES ('hello', 2, 0.2)")
+	database.Exec("INSERT INTO loadmodel (something, someint, somefloat) VALUES ('hillo', 1, 0.8)")
+	database.Exec("INSERT INTO loadmodel (something, someint, somefloat) VALUES ('hullo', 5, 1.3)")
+	database.Exec("INSERT INTO loadmodel (something, someint, somefloat) VALUES ('hollo', 4, 1.1)")
+
+	model := models.CreateModel(reflect.TypeOf(LoadModel{}))
+
+	statement := NewLoadStatement(database, &connection.SqliteInfo{}).Table(model.Table)
+	statement.Fields(xpr.Field(model, "Something"))
+	statement.Limit(xpr.Parameter()).Offset(1)
+
+	operation := statement.Prepare()
+	require.Equal(t, "SELECT [something] FROM loadmodel LIMIT ? OFFSET 1", operation.Command())
+
+	result, err := operation.ExecuteSet(2)
+
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{"hello", "hillo"}, result)
+}
+
+func TestLoadLimitOffsetParameterOrder(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(LoadModel{}))
+
+	// arguments are expected in order where, limit, offset in every database
+	expected := map[string]string{
+		"sqlite3":   "SELECT [something] FROM loadmodel WHERE [someint] > ? LIMIT ? OFFSET ?",
+		"mysql":     "SELECT `something` FROM loadmodel WHERE `someint` > ? LIMIT ? OFFSET ?",
+		"postgres":  `SELECT "something" FROM loadmodel WHERE "someint" > $1 LIMIT $2 OFFSET $3`,
+		"sqlserver": "SELECT [something] FROM loadmodel WHERE [someint] > @p1 ORDER BY (SELECT NULL) OFFSET @p3 ROWS FETCH NEXT @p2 ROWS ONLY"}
+
+	for drivername, command := range expected {
+		info, err := connection.Resolve(drivername)
+		require.NoError(t, err)
+
+		statement := NewLoadStatement(nil, info).Table(model.Table).Fields(xpr.Field(model, "Something"))
+		statement.Where(xpr.Grt(xpr.Field(model, "SomeInt"), xpr.Parameter()))
+		statement.Limit(xpr.Parameter()).Offset(xpr.Parameter())
+
+		operation := statement.Prepare()
+		require.NoError(t, operation.Err())
+		require.Equal(t, command, operation.Command(), drivername)
+	}
+
+	database, _ := sql.Open("sqlite3", ":memory:")
+	defer database.Close()
+
+	database.Exec("CREATE TABLE loadmodel (something string, someint int, somefloat real)")
+	database.Exec("INSERT INTO loadmodel (something, someint, somefloat) VALUES ('hallo', 0, 0.5)")
+	database.Exec("INSERT INTO loadmodel (something, someint, somefloat) VALUES ('hello', 2, 0.2)")
+	database.Exec("INSERT INTO loadmodel (something, someint, somefloat) VALUES ('hillo', 1, 0.8)")
+	database.Exec("INSERT INTO loadmodel (something, someint, somefloat) VALUES ('hullo', 5, 1.3)")
+
+	statement := NewLoadStatement(database, &connection.SqliteInfo{}).Table(model.Table).Fields(xpr.Field(model, "Something"))
+	statement.Where(xpr.Grt(xpr.Field(model, "SomeInt"), xpr.Parameter()))
+	statement.OrderBy(xpr.Field(model, "SomeInt"))
+	statement.Limit(xpr.Parameter()).Offset(xpr.Parameter())
+
+	result, err := statement.Prepare().ExecuteSet(0, 1, 2)
+
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{"hullo"}, result)
+}
+
+func TestLoadLimitError(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(LoadModel{}))
+
+	statement := NewLoadStatement(nil, &connection.SqliteInfo{}).Table(model.Table)
+	statement.Fields(xpr.Field(model, "Something"))
+	statement.Limit(xpr.Coalesce())
+
+	require.Error(t, statement.Prepare().Err())
+}
+
+func TestLoadNestedExpressionError(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(LoadModel{}))
+
+	where := NewLoadStatement(nil, &connection.SqliteInfo{}).Table(model.Table).Fields(xpr.Field(model, "Something"))
+	where.Where(xpr.And(xpr.Equals(xpr.Field(model, "SomeInt"), 1), xpr.Equals(xpr.Field(model, "Something"), xpr.Coalesce())))
+	require.Error(t, where.Prepare().Err())
+
+	join := NewLoadStatement(nil, &connection.SqliteInfo{}).Table(model.Table).Alias("l").Fields(xpr.AliasField("l", model, "Something"))
+	join.Join(JoinTypeInner, model.Table, xpr.Equals(xpr.AliasField("l", model, "SomeInt"), xpr.AliasField("j", model, "Missing")), "j")
+	require.Error(t, join.Prepare().Err())
+}
+
+func TestLoadOffsetWithoutLimit(t *testing.T) {
+	database, _ := sql.Open("sqlite3", ":memory:")
+	defer database.Close()
+
+	database.Exec("CREATE TABLE loadmodel (something string, someint int, somefloat real)")
+	database.Exec("INSERT INTO loadmodel (something, someint, somefloat) VALUES ('hallo', 0, 0.5)")
+	database.Exec("INSERT INTO loadmodel (something, someint, somefloat) VALUES ('hello', 2, 0.2)")
+	database.Exec("INSERT INTO loadmodel (something, someint, somefloat) VALUES ('hillo', 1, 0.8)")
+
+	model := models.CreateModel(reflect.TypeOf(LoadModel{}))
+
+	statement := NewLoadStatement(database, &connection.SqliteInfo{}).Table(model.Table)
+	statement.Fields(xpr.Field(model, "Something"))
+	statement.Offset(2)
+
+	result, err := statement.Prepare().ExecuteSet()
+
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{"hillo"}, result)
+}
+
+func TestLoadOrderBy(t *testing.T) {
+	database, _ := sql.Open("sqlite3", ":memory:")
+	defer database.Close()
+
+	database.Exec("CREATE TABLE loadmodel (something string, someint int, somefloat real)")
+	database.Exec("INSERT INTO loadmodel (something, someint, somefloat) VALUES ('hallo', 0, 0.5)")
+	database.Exec("INSERT INTO loadmodel (something, someint, somefloat) VALUES ('hello', 2, 0.2)")
+	database.Exec("INSERT INTO loadmodel (something, someint, somefloat) VALUES ('hillo', 2, 0.8)")
+	database.Exec("INSERT INTO loadmodel (something, someint, somefloat) VALUES ('hullo', NULL, 1.3)")
+
+	model := models.CreateModel(reflect.TypeOf(LoadModel{}))
+
+	statement := NewLoadStatement(database, &connection.SqliteInfo{}).Table(model.Table)
+	statement.Fields(xpr.Field(model, "Something"))
+	statement.OrderBy(xpr.Desc(xpr.Field(model, "SomeInt")).NullsFirst(), xpr.Asc(xpr.Field(model, "SomeFloat")))
+
+	operation := statement.Prepare()
+	require.Equal(t, "SELECT [something] FROM loadmodel ORDER BY [someint] DESC NULLS FIRST,[somefloat] ASC", operation.Command())
+
+	result, err := operation.ExecuteSet()
+
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{"hullo", "hello", "hillo", "hallo"}, result)
+}
+
+func TestLoadOrderByUnion(t *testing.T) {
+	database, _ := sql.Open("sqlite3", ":memory:")
+	defer database.Close()
+
+	database.Exec("CREATE TABLE loadmodel (something string, someint int, somefloat real)")
+	database.Exec("INSERT INTO loadmodel (something, someint, somefloat) VALUES ('hallo', 0, 0.5)")
+	database.Exec("INSERT INTO loadmodel (something, someint, somefloat) VALUES ('hello', 2, 0.2)")
+	database.Exec("INSERT INTO loadmodel (something, someint, somefloat) VALUES ('hillo', 1, 0.8)")
+
+	model := models.CreateModel(reflect.TypeOf(LoadModel{}))
+
+	union := NewLoadStatement(database, &connection.SqliteInfo{}).Table(model.Table)
+	union.Fields(xpr.Field(model, "Something")).Where(xpr.Grt(xpr.Field(model, "SomeInt"), 0))
+
+	statement := NewLoadStatement(database, &connection.SqliteInfo{}).Table(model.Table)
+	statement.Fields(xpr.Field(model, "Something")).Where(xpr.Equals(xpr.Field(model, "SomeInt"), 0))
+	statement.Union(union.Prepare(), false)
+	statement.OrderBy(xpr.Desc(xpr.Field(model, "Something"))).Limit(2)
+
+	result, err := statement.Prepare().ExecuteSet()
+
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{"hillo", "hello"}, result)
+}
@@ -0,0 +1,50 @@
+package statements
+
+import (
+	"fmt"
+)
+
+// UnmappedColumns determines how result columns are handled which are not mapped to a field of an entity
+type UnmappedColumns int
+
+const (
+	// UnmappedColumnsError fails mapping with a MappingError if a result column is not mapped to a field
+	UnmappedColumnsError UnmappedColumns = iota
+
+	// UnmappedColumnsIgnore ignores values of result columns not mapped to a field
+	UnmappedColumnsIgnore
+
+	// UnmappedColumnsCollect collects values of result columns not mapped to a field into the
+	// map[string]interface{} field of the entity which is tagged as `database:"extra"`
+	UnmappedColumnsCollect
+)
+
+// MappingError error which occured when mapping result rows to entities
+type MappingError struct {
+	Row    int    // index of result row, -1 if error does not relate to a specific row
+	Column string // name of result column, empty if error does not relate to a specific column
+	Err    error  // cause of the error
+}
+
+// Error message of the mapping error
+//
+// **Returns**
+//   - string: message describing the error
+func (err *MappingError) Error() string {
+	switch {
+	case err.Row < 0:
+		return fmt.Sprintf("Unable to map column '%s': %s", err.Column, err.Err.Error())
+	case len(err.Column) == 0:
+		return fmt.Sprintf("Unable to map row %d: %s", err.Row, err.Err.Error())
+	default:
+		return fmt.Sprintf("Unable to map column '%s' of row %d: %s", err.Column, err.Row, err.Err.Error())
+	}
+}
+
+// Unwrap cause of the error
+//
+// **Returns**
+//   - error: error which caused mapping to fail
+func (err *MappingError) Unwrap() error {
+	return err.Err
+}
@@ -0,0 +1,217 @@
+package statements
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/verticalgmbh/database-go/connection"
+	"github.com/verticalgmbh/database-go/entities/models"
+	"github.com/verticalgmbh/database-go/xpr"
+)
+
+type SizedEntity struct {
+	Code  string `database:"size=16,notnull"`
+	Notes string
+}
+
+func TestMySQLLoad(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	statement := NewLoadStatement(nil, connection.NewMySQLInfo()).Model(model)
+	statement.Where(xpr.And(xpr.Equals(xpr.Field(model, "Name"), xpr.Parameter()), xpr.Grt(xpr.Field(model, "Counter"), xpr.Parameter())))
+
+	require.Equal(t, "SELECT `id`,`name`,`counter`,`active`,`created`,`data` FROM dialectentity WHERE `name` = ? AND `counter` > ?", statement.Prepare().Command())
+}
+
+func TestMySQLLoadNamedParameter(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	statement := NewLoadStatement(nil, connection.NewMySQLInfo()).Model(model)
+	statement.Where(xpr.Equals(xpr.Field(model, "Name"), xpr.NamedParameter("name")))
+
+	require.Error(t, statement.Prepare().Err())
+}
+
+func TestMySQLLoadJoinGroupUnion(t *testing.T) {
+	info := connection.NewMySQLInfo()
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	union := NewLoadStatement(nil, info).Table("archive").Fields(xpr.Column("name"), xpr.Max(xpr.Column("counter")))
+
+	statement := NewLoadStatement(nil, info).Table(model.Table).Alias("e")
+	statement.Fields(xpr.AliasField("e", model, "Name"), xpr.Max(xpr.AliasField("o", model, "Counter")))
+	statement.Join(JoinTypeInner, "other", xpr.Equals(xpr.AliasField("o", model, "ID"), xpr.AliasField("e", model, "ID")), "o")
+	statement.GroupBy(xpr.AliasField("e", model, "Name"))
+	statement.Union(union.Prepare(), true)
+
+	require.Equal(t, "SELECT e.`name`,MAX(o.`counter`) FROM dialectentity AS e INNER JOIN other AS o ON o.`id` = e.`id` GROUP BY e.`name` UNION ALL SELECT `name`,MAX(`counter`) FROM archive", statement.Prepare().Command())
+}
+
+func TestMySQLInsert(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	statement := NewInsertStatement(model, nil, connection.NewMySQLInfo()).Columns("Name", "Counter", "Created")
+
+	require.Equal(t, "INSERT INTO dialectentity (`name`,`counter`,`created`) VALUES(?,?,?)", statement.Prepare().Command())
+}
+
+func TestMySQLInsertReturnID(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	statement := NewInsertStatement(model, nil, connection.NewMySQLInfo()).Columns("Name", "Counter").ReturnID()
+	operation := statement.Prepare()
+
+	require.Equal(t, "INSERT INTO dialectentity (`name`,`counter`) VALUES(?,?)", operation.Command())
+	require.False(t, operation.loadresult)
+	require.True(t, operation.lastinsertid)
+}
+
+func TestMySQLInsertLoad(t *testing.T) {
+	info := connection.NewMySQLInfo()
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	load := NewLoadStatement(nil, info).Table("archive").Fields(xpr.Column("name"), xpr.Column("counter")).Where(xpr.Equals(xpr.Column("active"), xpr.Parameter()))
+	statement := NewInsertLoad(model, nil, info).Fields("Name", "Counter").Load(load)
+
+	require.Equal(t, "INSERT INTO dialectentity (`name`,`counter`) SELECT `name`,`counter` FROM archive WHERE `active` = ?", statement.Prepare().Command())
+}
+
+func TestMySQLUpdate(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	statement := NewUpdateStatement(model, nil, connection.NewMySQLInfo())
+	statement.Set(xpr.Assign(xpr.Field(model, "Counter"), xpr.Add(xpr.Field(model, "Counter"), xpr.Parameter())))
+	statement.Where(xpr.Equals(xpr.Field(model, "ID"), xpr.Parameter()))
+
+	require.Equal(t, "UPDATE dialectentity SET `counter` = `counter` + ? WHERE `id` = ?", statement.Prepare().Command())
+}
+
+func TestMySQLDelete(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	statement := NewDeleteStatement(model, nil, connection.NewMySQLInfo())
+	statement.Where(xpr.Equals(xpr.Field(model, "ID"), xpr.Parameter()))
+
+	require.Equal(t, "DELETE FROM dialectentity WHERE `id` = ?", statement.Prepare().Command())
+}
+
+func TestMySQLCreate(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	statement := NewCreateStatement(model, nil, connection.NewMySQLInfo())
+
+	require.Equal(t, "CREATE TABLE dialectentity (`id` BIGINT PRIMARY KEY AUTO_INCREMENT,`name` VARCHAR(255) UNIQUE NOT NULL,`counter` INT,`active` BOOLEAN DEFAULT true,`created` DATETIME,`data` BLOB)", statement.Prepare().Command())
+}
+
+func TestMySQLCreateSized(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(SizedEntity{}))
+
+	statement := NewCreateStatement(model, nil, connection.NewMySQLInfo())
+
+	require.Equal(t, "CREATE TABLE sizedentity (`code` VARCHAR(16) NOT NULL,`notes` TEXT)", statement.Prepare().Command())
+}
+
+func TestMySQLCreateIndex(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	statement := NewCreateIndexStatement(model, model.Indices()[0], nil, connection.NewMySQLInfo())
+
+	require.Equal(t, "CREATE INDEX idx_dialectentity_counter ON dialectentity (`counter`);", statement.Prepare().Command())
+}
+
+func TestMySQLAddColumn(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	statement := NewAddColumnStatement(nil, connection.NewMySQLInfo(), model, model.ColumnFromField("Created"))
+
+	require.Equal(t, "ALTER TABLE dialectentity ADD COLUMN `created` DATETIME", statement.Prepare().Command())
+}
+
+func TestMySQLAddUnique(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	statement := NewAddUnique(nil, connection.NewMySQLInfo(), model, models.NewIndexDescriptor("", "name", "counter"))
+
+	require.Equal(t, "ALTER TABLE dialectentity ADD UNIQUE(`name`,`counter`)", statement.Prepare().Command())
+}
+
+func TestMySQLDropIndex(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	statement := NewDropIndex(nil, connection.NewMySQLInfo(), model, "idx_dialectentity_counter")
+
+	require.Equal(t, "DROP INDEX idx_dialectentity_counter ON dialectentity", statement.Prepare().Command())
+}
+
+func TestMySQLDropTable(t *testing.T) {
+	statement := NewDropTable(nil, connection.NewMySQLInfo(), "dialectentity")
+
+	require.Equal(t, "DROP TABLE dialectentity", statement.Prepare().Command())
+}
+
+func TestMySQLRenameTable(t *testing.T) {
+	statement := NewRenameTable(nil, connection.NewMySQLInfo(), "dialectentity", "dialectentity_original")
+
+	require.Equal(t, "ALTER TABLE dialectentity RENAME TO dialectentity_original", statement.Prepare().Command())
+}
+
+func TestMySQLLoadLimitOffset(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	statement := NewLoadStatement(nil, connection.NewMySQLInfo()).Table(model.Table).Fields(xpr.Field(model, "Name"))
+	statement.Limit(10).Offset(20)
+
+	require.Equal(t, "SELECT `name` FROM dialectentity LIMIT 10 OFFSET 20", statement.Prepare().Command())
+}
+
+func TestMySQLLoadOffsetWithoutLimit(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	statement := NewLoadStatement(nil, connection.NewMySQLInfo()).Table(model.Table).Fields(xpr.Field(model, "Name"))
+	statement.Offset(xpr.Parameter())
+
+	require.Equal(t, "SELECT `name` FROM dialectentity LIMIT 18446744073709551615 OFFSET ?", statement.Prepare().Command())
+}
+
+func TestMySQLLoadOrderByNulls(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	statement := NewLoadStatement(nil, connection.NewMySQLInfo()).Table(model.Table).Fields(xpr.Field(model, "Name"))
+	statement.OrderBy(xpr.Desc(xpr.Field(model, "Counter")).NullsLast())
+
+	require.Equal(t, "SELECT `name` FROM dialectentity ORDER BY CASE WHEN `counter` IS NULL THEN 1 ELSE 0 END,`counter` DESC", statement.Prepare().Command())
+}
+
+func TestMySQLLoadJoins(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	statement := NewLoadStatement(nil, connection.NewMySQLInfo()).Table(model.Table).Alias("e")
+	statement.Fields(xpr.AliasField("e", model, "Name"))
+	statement.Join(JoinTypeLeft, "other", xpr.Equals(xpr.AliasField("o", model, "ID"), xpr.AliasField("e", model, "ID")), "o")
+	statement.Join(JoinTypeRight, "archive", xpr.Equals(xpr.AliasField("a", model, "ID"), xpr.AliasField("e", model, "ID")), "a")
+
+	operation := statement.Prepare()
+
+	require.NoError(t, operation.Err())
+	require.Equal(t, "SELECT e.`name` FROM dialectentity AS e LEFT OUTER JOIN other AS o ON o.`id` = e.`id` RIGHT OUTER JOIN archive AS a ON a.`id` = e.`id`", operation.Command())
+}
+
+func TestMySQLLoadFullJoinUnsupported(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	statement := NewLoadStatement(nil, connection.NewMySQLInfo()).Table(model.Table).Alias("e")
+	statement.Fields(xpr.AliasField("e", model, "Name"))
+	statement.Join(JoinTypeFull, "other", xpr.Equals(xpr.AliasField("o", model, "ID"), xpr.AliasField("e", model, "ID")), "o")
+
+	require.Error(t, statement.Prepare().Err())
+}
+
+func TestMySQLReturningUnsupported(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	statement := NewDeleteStatement(model, nil, connection.NewMySQLInfo()).Where(xpr.Equals(xpr.Field(model, "Active"), xpr.Parameter()))
+
+	require.Error(t, statement.PrepareReturning().Err())
+	require.NoError(t, statement.Prepare().Err())
+}
@@ -0,0 +1,344 @@
+package statements
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/verticalgmbh/database-go/connection"
+	"github.com/verticalgmbh/database-go/entities/models"
+	"github.com/verticalgmbh/database-go/xpr"
+)
+
+type DialectEntity struct {
+	ID      int64  `database:"primarykey,autoincrement"`
+	Name    string `database:"unique,notnull"`
+	Counter int32  `database:"index=counter"`
+	Active  bool   `database:"default=true"`
+	Created time.Time
+	Data    []byte
+}
+
+func TestPostgresLoad(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	statement := NewLoadStatement(nil, connection.NewPostgresInfo()).Model(model)
+	statement.Where(xpr.And(xpr.Equals(xpr.Field(model, "Name"), xpr.Parameter()), xpr.Grt(xpr.Field(model, "Counter"), xpr.Parameter())))
+
+	require.Equal(t, `SELECT "id","name","counter","active","created","data" FROM dialectentity WHERE "name" = $1 AND "counter" > $2`, statement.Prepare().Command())
+}
+
+func TestPostgresLoadJoinGroupUnion(t *testing.T) {
+	info := connection.NewPostgresInfo()
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	union := NewLoadStatement(nil, info).Table("archive").Fields(xpr.Column("name"), xpr.Count())
+
+	statement := NewLoadStatement(nil, info).Table(model.Table).Alias("e")
+	statement.Fields(xpr.AliasField("e", model, "Name"), xpr.Max(xpr.AliasField("o", model, "Counter")))
+	statement.Join(JoinTypeInner, "other", xpr.Equals(xpr.AliasField("o", model, "ID"), xpr.AliasField("e", model, "ID")), "o")
+	statement.GroupBy(xpr.AliasField("e", model, "Name"))
+	statement.Union(union.Prepare(), true)
+
+	require.Equal(t, `SELECT e."name",MAX(o."counter") FROM dialectentity AS e INNER JOIN other AS o ON o."id" = e."id" GROUP BY e."name" UNION ALL SELECT "name",COUNT(*) FROM archive`, statement.Prepare().Command())
+}
+
+func TestPostgresInsert(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	statement := NewInsertStatement(model, nil, connection.NewPostgresInfo()).Columns("Name", "Counter", "Created")
+
+	require.Equal(t, `INSERT INTO dialectentity ("name","counter","created") VALUES($1,$2,$3)`, statement.Prepare().Command())
+}
+
+func TestPostgresInsertReturnID(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	statement := NewInsertStatement(model, nil, connection.NewPostgresInfo()).Columns("Name", "Counter").ReturnID()
+	operation := statement.Prepare()
+
+	require.Equal(t, `INSERT INTO dialectentity ("name","counter") VALUES($1,$2) RETURNING "id"`, operation.Command())
+	require.True(t, operation.loadresult)
+	require.False(t, operation.lastinsertid)
+}
+
+func TestPostgresInsertReturnIDWithoutIdentity(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(InsertModel{}))
+
+	operation := NewInsertStatement(model, nil, connection.NewPostgresInfo()).Columns("Something").ReturnID().Prepare()
+
+	require.Error(t, operation.Err())
+}
+
+func TestPostgresInsertLoad(t *testing.T) {
+	info := connection.NewPostgresInfo()
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	load := NewLoadStatement(nil, info).Table("archive").Fields(xpr.Column("name"), xpr.Column("counter")).Where(xpr.Equals(xpr.Column("active"), xpr.Parameter()))
+	statement := NewInsertLoad(model, nil, info).Fields("Name", "Counter").Load(load)
+
+	require.Equal(t, `INSERT INTO dialectentity ("name","counter") SELECT "name","counter" FROM archive WHERE "active" = $1`, statement.Prepare().Command())
+}
+
+func TestPostgresUpdate(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	statement := NewUpdateStatement(model, nil, connection.NewPostgresInfo())
+	statement.Set(xpr.Assign(xpr.Field(model, "Counter"), xpr.Add(xpr.Field(model, "Counter"), xpr.Parameter())), xpr.Assign(xpr.Field(model, "Active"), xpr.Parameter()))
+	statement.Where(xpr.Equals(xpr.Field(model, "ID"), xpr.Parameter()))
+
+	require.Equal(t, `UPDATE dialectentity SET "counter" = "counter" + $1,"active" = $2 WHERE "id" = $3`, statement.Prepare().Command())
+}
+
+func TestPostgresDelete(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	statement := NewDeleteStatement(model, nil, connection.NewPostgresInfo())
+	statement.Where(xpr.In(xpr.Field(model, "ID"), xpr.Parameter(), xpr.Parameter()))
+
+	require.Equal(t, `DELETE FROM dialectentity WHERE "id" IN ($1,$2)`, statement.Prepare().Command())
+}
+
+func TestPostgresNamedParameter(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	statement := NewDeleteStatement(model, nil, connection.NewPostgresInfo())
+	statement.Where(xpr.Or(xpr.Equals(xpr.Field(model, "ID"), xpr.NamedParameter("2")), xpr.Equals(xpr.Field(model, "Name"), xpr.Parameter())))
+
+	require.Equal(t, `DELETE FROM dialectentity WHERE "id" = $2 OR "name" = $3`, statement.Prepare().Command())
+}
+
+func TestPostgresRepeatedNamedParameter(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	statement := NewLoadStatement(nil, connection.NewPostgresInfo()).Model(model)
+	statement.Where(xpr.And(xpr.Or(xpr.Equals(xpr.Field(model, "Name"), xpr.NamedParameter("name")), xpr.Equals(xpr.Field(model, "Name"), "$7")), xpr.And(xpr.Grt(xpr.Field(model, "Counter"), xpr.Parameter()), xpr.EqualsNot(xpr.Field(model, "Name"), xpr.NamedParameter("name")))))
+
+	require.Equal(t, `SELECT "id","name","counter","active","created","data" FROM dialectentity WHERE "name" = $1 OR "name" = '$7' AND "counter" > $2 AND "name" <> $1`, statement.Prepare().Command())
+}
+
+func TestPostgresCreate(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	statement := NewCreateStatement(model, nil, connection.NewPostgresInfo())
+
+	require.Equal(t, `CREATE TABLE dialectentity ("id" BIGSERIAL PRIMARY KEY,"name" TEXT UNIQUE NOT NULL,"counter" INTEGER,"active" BOOLEAN DEFAULT true,"created" TIMESTAMPTZ,"data" BYTEA)`, statement.Prepare().Command())
+}
+
+func TestPostgresCreateIndex(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	statement := NewCreateIndexStatement(model, model.Indices()[0], nil, connection.NewPostgresInfo())
+
+	require.Equal(t, "DROP INDEX IF EXISTS idx_dialectentity_counter;\nCREATE INDEX idx_dialectentity_counter ON dialectentity (\"counter\");", statement.Prepare().Command())
+}
+
+func TestPostgresAddColumn(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	statement := NewAddColumnStatement(nil, connection.NewPostgresInfo(), model, model.ColumnFromField("Created"))
+
+	require.Equal(t, `ALTER TABLE dialectentity ADD COLUMN "created" TIMESTAMPTZ`, statement.Prepare().Command())
+}
+
+func TestPostgresAddUnique(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	statement := NewAddUnique(nil, connection.NewPostgresInfo(), model, models.NewIndexDescriptor("", "name", "counter"))
+
+	require.Equal(t, `ALTER TABLE dialectentity ADD UNIQUE("name","counter")`, statement.Prepare().Command())
+}
+
+func TestPostgresDropIndex(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	statement := NewDropIndex(nil, connection.NewPostgresInfo(), model, "idx_dialectentity_counter")
+
+	require.Equal(t, `DROP INDEX idx_dialectentity_counter`, statement.Prepare().Command())
+}
+
+func TestPostgresDropTable(t *testing.T) {
+	statement := NewDropTable(nil, connection.NewPostgresInfo(), "dialectentity")
+
+	require.Equal(t, `DROP TABLE dialectentity`, statement.Prepare().Command())
+}
+
+func TestPostgresRenameTable(t *testing.T) {
+	statement := NewRenameTable(nil, connection.NewPostgresInfo(), "dialectentity", "dialectentity_original")
+
+	require.Equal(t, `ALTER TABLE dialectentity RENAME TO dialectentity_original`, statement.Prepare().Command())
+}
+
+func TestPostgresLoadLimitOffset(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	statement := NewLoadStatement(nil, connection.NewPostgresInfo()).Table(model.Table).Fields(xpr.Field(model, "Name"))
+	statement.Where(xpr.Equals(xpr.Field(model, "Active"), xpr.Parameter()))
+	statement.Limit(xpr.Parameter()).Offset(xpr.Parameter())
+
+	require.Equal(t, `SELECT "name" FROM dialectentity WHERE "active" = $1 LIMIT $2 OFFSET $3`, statement.Prepare().Command())
+}
+
+func TestPostgresLoadOrderBy(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	statement := NewLoadStatement(nil, connection.NewPostgresInfo()).Table(model.Table).Fields(xpr.Field(model, "Name"))
+	statement.OrderBy(xpr.Asc(xpr.Field(model, "Counter")).NullsLast(), xpr.Field(model, "Name"))
+	statement.Limit(10)
+
+	require.Equal(t, `SELECT "name" FROM dialectentity ORDER BY "counter" ASC NULLS LAST,"name" LIMIT 10`, statement.Prepare().Command())
+}
+
+func TestPostgresLoadHaving(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	statement := NewLoadStatement(nil, connection.NewPostgresInfo()).Table(model.Table).Fields(xpr.Field(model, "Name"), xpr.Max(xpr.Field(model, "Counter")))
+	statement.Where(xpr.Equals(xpr.Field(model, "Active"), xpr.Parameter()))
+	statement.GroupBy(xpr.Field(model, "Name"))
+	statement.Having(xpr.Grt(xpr.Max(xpr.Field(model, "Counter")), xpr.Parameter()))
+
+	require.Equal(t, `SELECT "name",MAX("counter") FROM dialectentity WHERE "active" = $1 GROUP BY "name" HAVING MAX("counter") > $2`, statement.Prepare().Command())
+}
+
+func TestPostgresLoadOuterJoins(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	statement := NewLoadStatement(nil, connection.NewPostgresInfo()).Table(model.Table).Alias("e")
+	statement.Fields(xpr.AliasField("e", model, "Name"), xpr.AliasColumn("r", "name"))
+	statement.Join(JoinTypeFull, "other", xpr.Equals(xpr.AliasField("o", model, "ID"), xpr.AliasField("e", model, "ID")), "o")
+	statement.Join(JoinTypeRight, "archive", xpr.Equals(xpr.AliasField("a", model, "ID"), xpr.AliasField("e", model, "ID")), "a")
+	statement.Join(JoinTypeCross, "region", nil, "r")
+
+	operation := statement.Prepare()
+
+	require.NoError(t, operation.Err())
+	require.Equal(t, `SELECT e."name",r."name" FROM dialectentity AS e FULL OUTER JOIN other AS o ON o."id" = e."id" RIGHT OUTER JOIN archive AS a ON a."id" = e."id" CROSS JOIN region AS r`, operation.Command())
+}
+
+func TestPostgresLoadJoinSubquery(t *testing.T) {
+	info := connection.NewPostgresInfo()
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	subquery := NewLoadStatement(nil, info).Table("other").Fields(xpr.Column("id"), xpr.Max(xpr.Column("counter")))
+	subquery.Where(xpr.Equals(xpr.Column("active"), xpr.Parameter()))
+	subquery.GroupBy(xpr.Column("id"))
+
+	statement := NewLoadStatement(nil, info).Model(model).Alias("e")
+	statement.Join(JoinTypeLeft, subquery, xpr.Equals(xpr.AliasColumn("o", "id"), xpr.AliasField("e", model, "ID")), "o")
+	statement.Where(xpr.Equals(xpr.AliasField("e", model, "Name"), xpr.Parameter()))
+
+	operation := statement.Prepare()
+
+	require.NoError(t, operation.Err())
+	require.Equal(t, `SELECT "id","name","counter","active","created","data" FROM dialectentity AS e LEFT OUTER JOIN (SELECT "id",MAX("counter") FROM other WHERE "active" = $1 GROUP BY "id") AS o ON o."id" = e."id" WHERE e."name" = $2`, operation.Command())
+}
+
+func TestPostgresLoadPreparedSubquery(t *testing.T) {
+	info := connection.NewPostgresInfo()
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	subquery := NewLoadStatement(nil, info).Table("other").Fields(xpr.Column("id")).Where(xpr.Equals(xpr.Column("active"), xpr.Parameter())).Prepare()
+	filter := NewLoadStatement(nil, info).Table("filter").Fields(xpr.Column("name")).Where(xpr.Equals(xpr.Column("counter"), xpr.Parameter())).Prepare()
+	archive := NewLoadStatement(nil, info).Table("archive").Fields(xpr.Column("id")).Where(xpr.Equals(xpr.Column("counter"), xpr.Parameter())).Prepare()
+
+	statement := NewLoadStatement(nil, info).Table(model.Table).Alias("e").Fields(xpr.AliasField("e", model, "ID"))
+	statement.Join(JoinTypeInner, subquery, xpr.Equals(xpr.AliasColumn("o", "id"), xpr.AliasField("e", model, "ID")), "o")
+	statement.Where(xpr.Equals(xpr.AliasField("e", model, "Name"), xpr.Statement(filter)))
+	statement.Union(archive, false)
+
+	operation := statement.Prepare()
+
+	require.NoError(t, operation.Err())
+	require.Equal(t, `SELECT e."id" FROM dialectentity AS e INNER JOIN (SELECT "id" FROM other WHERE "active" = $1) AS o ON o."id" = e."id" WHERE e."name" = (SELECT "name" FROM filter WHERE "counter" = $2) UNION SELECT "id" FROM archive WHERE "counter" = $3`, operation.Command())
+
+	// prepared statements keep their own numbering when executed on their own
+	require.Equal(t, `SELECT "id" FROM archive WHERE "counter" = $1`, archive.Command())
+}
+
+func TestPostgresLoadWithRecursive(t *testing.T) {
+	info := connection.NewPostgresInfo()
+
+	anchor := NewLoadStatement(nil, info).Table("category").Fields(xpr.Column("id")).Where(xpr.Equals(xpr.Column("id"), xpr.Parameter()))
+	recursive := NewLoadStatement(nil, info).Table("category").Alias("c").Fields(xpr.AliasColumn("c", "id"))
+	recursive.Join(JoinTypeInner, xpr.Table("tree"), xpr.Equals(xpr.AliasColumn("c", "parent"), xpr.AliasColumn("t", "id")), "t")
+
+	statement := NewLoadStatement(nil, info).WithRecursive("tree", anchor, recursive).Table("tree").Fields(xpr.Column("id"))
+	statement.Limit(xpr.Parameter())
+
+	require.Equal(t, `WITH RECURSIVE tree AS (SELECT "id" FROM category WHERE "id" = $1 UNION ALL SELECT c."id" FROM category AS c INNER JOIN tree AS t ON c."parent" = t."id") SELECT "id" FROM tree LIMIT $2`, statement.Prepare().Command())
+}
+
+func TestPostgresLoadSetOperations(t *testing.T) {
+	info := connection.NewPostgresInfo()
+
+	archive := NewLoadStatement(nil, info).Table("archive").Fields(xpr.Column("name")).Where(xpr.Equals(xpr.Column("counter"), xpr.Parameter()))
+	deleted := NewLoadStatement(nil, info).Table("deleted").Fields(xpr.Column("name")).Where(xpr.Equals(xpr.Column("counter"), xpr.Parameter()))
+
+	statement := NewLoadStatement(nil, info).Table("dialectentity").Fields(xpr.Column("name")).Where(xpr.Equals(xpr.Column("counter"), xpr.Parameter()))
+	statement.UnionAll(archive).Except(deleted).OrderBy(xpr.Column("name"))
+
+	require.Equal(t, `SELECT "name" FROM dialectentity WHERE "counter" = $1 UNION ALL SELECT "name" FROM archive WHERE "counter" = $2 EXCEPT SELECT "name" FROM deleted WHERE "counter" = $3 ORDER BY "name"`, statement.Prepare().Command())
+}
+
+func TestPostgresLoadMixedSetOperations(t *testing.T) {
+	info := connection.NewPostgresInfo()
+
+	archive := NewLoadStatement(nil, info).Table("archive").Fields(xpr.Column("name")).Where(xpr.Equals(xpr.Column("counter"), xpr.Parameter()))
+	active := NewLoadStatement(nil, info).Table("active").Fields(xpr.Column("name"))
+	deleted := NewLoadStatement(nil, info).Table("deleted").Fields(xpr.Column("name")).Where(xpr.Equals(xpr.Column("counter"), xpr.Parameter()))
+	hidden := NewLoadStatement(nil, info).Table("hidden").Fields(xpr.Column("name"))
+	deleted.Union(hidden, false)
+
+	statement := NewLoadStatement(nil, info).Table("dialectentity").Fields(xpr.Column("name")).Where(xpr.Equals(xpr.Column("counter"), xpr.Parameter()))
+	statement.Union(archive, false).Intersect(active).Except(deleted.Prepare()).OrderBy(xpr.Column("name"))
+
+	operation := statement.Prepare()
+
+	require.NoError(t, operation.Err())
+	require.Equal(t, `SELECT * FROM (SELECT "name" FROM dialectentity WHERE "counter" = $1 UNION SELECT "name" FROM archive WHERE "counter" = $2) AS combined1 INTERSECT SELECT "name" FROM active EXCEPT SELECT * FROM (SELECT "name" FROM deleted WHERE "counter" = $3 UNION SELECT "name" FROM hidden) AS operand2 ORDER BY "name"`, operation.Command())
+}
+
+func TestPostgresLoadSetOperandLimit(t *testing.T) {
+	info := connection.NewPostgresInfo()
+
+	archive := NewLoadStatement(nil, info).Table("archive").Fields(xpr.Column("name")).OrderBy(xpr.Column("name")).Limit(5)
+	statement := NewLoadStatement(nil, info).Table("dialectentity").Fields(xpr.Column("name")).Union(archive, true)
+
+	require.Error(t, statement.Prepare().Err())
+}
+
+func TestPostgresBatchInsert(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	statement := NewInsertStatement(model, nil, connection.NewPostgresInfo()).Columns("Name", "Counter")
+	operation := statement.PrepareBatch()
+
+	require.Equal(t, 32767, operation.BatchSize())
+	require.Equal(t, `INSERT INTO dialectentity ("name","counter") VALUES($1,$2),($3,$4),($5,$6)`, operation.Command(3))
+}
+
+func TestPostgresInsertReturning(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	statement := NewInsertStatement(model, nil, connection.NewPostgresInfo()).Columns("Name", "Counter").Returning(xpr.Field(model, "ID"), xpr.Field(model, "Created"))
+	operation := statement.PrepareReturning()
+
+	require.NoError(t, operation.Err())
+	require.Equal(t, `INSERT INTO dialectentity ("name","counter") VALUES($1,$2) RETURNING "id","created"`, operation.Command())
+	require.Equal(t, `INSERT INTO dialectentity ("name","counter") VALUES($1,$2)`, statement.Prepare().Command())
+
+	statement.ReturnID()
+	require.Error(t, statement.Prepare().Err())
+	require.Error(t, statement.PrepareReturning().Err())
+}
+
+func TestPostgresUpdateReturningAll(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	statement := NewUpdateStatement(model, nil, connection.NewPostgresInfo()).Set(xpr.Assign(xpr.Field(model, "Counter"), xpr.Parameter())).Where(xpr.Equals(xpr.Field(model, "Name"), xpr.Parameter()))
+	operation := statement.PrepareReturning()
+
+	require.NoError(t, operation.Err())
+	require.Equal(t, `UPDATE dialectentity SET "counter" = $1 WHERE "name" = $2 RETURNING "id","name","counter","active","created","data"`, operation.Command())
+}
@@ -0,0 +1,186 @@
+package statements
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/verticalgmbh/database-go/connection"
+	"github.com/verticalgmbh/database-go/interfaces"
+	"github.com/verticalgmbh/database-go/xpr"
+)
+
+// PreparedBatchStatement - statement used to insert multiple rows at once. Rows are split into chunks
+//                          respecting the maximum number of parameters supported by the database
+type PreparedBatchStatement struct {
+	header         string // command part preceding the values
+	columns        int    // number of values per row
+	batchsize      int    // maximum number of rows per command
+	connection     interfaces.IExecutor
+	connectioninfo connection.IConnectionInfo
+	err            error // error which occured when preparing the statement
+
+	commands map[int]string // commands already built by number of rows
+}
+
+// Command sql command string used to insert a number of rows
+//
+// **Parameters**
+//   - rows: number of rows to insert using the command
+//
+// **Returns**
+//   - string: sql-command
+func (statement *PreparedBatchStatement) Command(rows int) string {
+	if command, ok := statement.commands[rows]; ok {
+		return command
+	}
+
+	var command strings.Builder
+	command.WriteString(statement.header)
+	command.WriteString(" VALUES")
+
+	parameters := connection.NewParameters()
+	for row := 0; row < rows; row++ {
+		if row > 0 {
+			command.WriteRune(',')
+		}
+
+		command.WriteRune('(')
+		for column := 0; column < statement.columns; column++ {
+			if column > 0 {
+				command.WriteRune(',')
+			}
+
+			// positional parameters are supported by all databases so evaluation can not fail
+			_ = statement.connectioninfo.EvaluateParameter(xpr.Parameter(), parameters, &command)
+		}
+		command.WriteRune(')')
+	}
+
+	if statement.commands == nil {
+		statement.commands = make(map[int]string)
+	}
+	statement.commands[rows] = command.String()
+	return statement.commands[rows]
+}
+
+// Err error which occured when preparing the statement
+//
+// **Returns**
+//   - error: error if statement could not get prepared, nil otherwise
+func (statement *PreparedBatchStatement) Err() error {
+	return statement.err
+}
+
+// BatchSize maximum number of rows inserted using a single command
+//
+// **Returns**
+//   - int: number of rows
+func (statement *PreparedBatchStatement) BatchSize() int {
+	return statement.batchsize
+}
+
+// Execute inserts rows into the database. If rows have to be split into multiple commands and an error occurs,
+//         rows of commands already executed remain in the database. Use ExecuteTransaction if this is not wanted.
+//
+// **Parameters**
+//   - rows: values of rows to insert
+//
+// **Returns**
+//   - int64: number of affected rows
+//   - error: error if any occured
+func (statement *PreparedBatchStatement) Execute(rows ...[]interface{}) (int64, error) {
+	return statement.ExecuteTransactionContext(context.Background(), nil, rows...)
+}
+
+// ExecuteContext inserts rows into the database. If rows have to be split into multiple commands and an error occurs,
+//                rows of commands already executed remain in the database. Use ExecuteTransactionContext if this is not wanted.
+//
+// **Parameters**
+//   - ctx:  context used to cancel execution
+//   - rows: values of rows to insert
+//
+// **Returns**
+//   - int64: number of affected rows
+//   - error: error if any occured
+func (statement *PreparedBatchStatement) ExecuteContext(ctx context.Context, rows ...[]interface{}) (int64, error) {
+	return statement.ExecuteTransactionContext(ctx, nil, rows...)
+}
+
+// ExecuteTransaction inserts rows into the database using a transaction
+//
+// **Parameters**
+//   - transaction: transaction used to execute statement
+//   - rows:        values of rows to insert
+//
+// **Returns**
+//   - int64: number of affected rows
+//   - error: error if any occured
+func (statement *PreparedBatchStatement) ExecuteTransaction(transaction *sql.Tx, rows ...[]interface{}) (int64, error) {
+	return statement.ExecuteTransactionContext(context.Background(), transaction, rows...)
+}
+
+// ExecuteTransactionContext inserts rows into the database using a transaction
+//
+// **Parameters**
+//   - ctx:         context used to cancel execution
+//   - transaction: transaction used to execute statement
+//   - rows:        values of rows to insert
+//
+// **Returns**
+//   - int64: number of affected rows
+//   - error: error if any occured
+func (statement *PreparedBatchStatement) ExecuteTransactionContext(ctx context.Context, transaction *sql.Tx, rows ...[]interface{}) (int64, error) {
+	return statement.ExecuteExecutorContext(ctx, executor(statement.connection, transaction), rows...)
+}
+
+// ExecuteExecutorContext inserts rows into the database using an executor like a *sql.DB, *sql.Tx or *sql.Conn
+//
+// **Parameters**
+//   - ctx:      context used to cancel execution
+//   - executor: executor used to execute statement
+//   - rows:     values of rows to insert
+//
+// **Returns**
+//   - int64: number of affected rows
+//   - error: error if any occured
+func (statement *PreparedBatchStatement) ExecuteExecutorContext(ctx context.Context, executor interfaces.IExecutor, rows ...[]interface{}) (int64, error) {
+	if statement.err != nil {
+		return 0, statement.err
+	}
+
+	for index, row := range rows {
+		if len(row) != statement.columns {
+			return 0, fmt.Errorf("Row %d contains %d values but statement expects %d", index, len(row), statement.columns)
+		}
+	}
+
+	var affected int64
+	for start := 0; start < len(rows); start += statement.batchsize {
+		end := start + statement.batchsize
+		if end > len(rows) {
+			end = len(rows)
+		}
+
+		arguments := make([]interface{}, 0, (end-start)*statement.columns)
+		for _, row := range rows[start:end] {
+			arguments = append(arguments, row...)
+		}
+
+		command := statement.Command(end - start)
+
+		result, err := executor.ExecContext(ctx, command, arguments...)
+		if err != nil {
+			return affected, fmt.Errorf("Error executing batch insert: %w", err)
+		}
+
+		count, err := result.RowsAffected()
+		if err != nil {
+			return affected, err
+		}
+		affected += count
+	}
+
+	return affected, nil
+}
@@ -1,23 +1,26 @@
 package statements
 
 import (
+	"context"
 	"database/sql"
 	"errors"
-	"log"
-	"reflect"
-	"unsafe"
+	"strings"
 
 	"github.com/verticalgmbh/database-go/entities/models"
 
 	"github.com/verticalgmbh/database-go/connection"
+	"github.com/verticalgmbh/database-go/interfaces"
 )
 
 // PreparedLoadStatement statement used to load data from the database
 type PreparedLoadStatement struct {
 	command        string
-	connection     *sql.DB
+	connection     interfaces.IExecutor
 	connectioninfo connection.IConnectionInfo
 	model          *models.EntityModel // model on which select was based on
+	err            error               // error which occured when building the command
+	unmapped       UnmappedColumns     // determines how result columns not mapped to a field are handled
+	load           *LoadStatement      // statement the command was built from, nil if not built by a LoadStatement
 
 	prepared *sql.Stmt
 }
@@ -30,6 +33,36 @@ func (statement *PreparedLoadStatement) Command() string {
 	return statement.command
 }
 
+// WriteCommand writes the command of the statement as part of another command like a subquery or a set operation.
+//              The command is built again using the parameters of the other command, so databases using
+//              indexed parameters continue numbering instead of repeating indices.
+//
+// **Parameters**
+//   - command:    command to write the statement to
+//   - parameters: parameters already written to the command
+//
+// **Returns**
+//   - error: error if the statement can not be written as part of another command
+func (statement *PreparedLoadStatement) WriteCommand(command *strings.Builder, parameters *connection.Parameters) error {
+	if statement.err != nil {
+		return statement.err
+	}
+
+	if statement.load == nil {
+		return errors.New("Only statements prepared from a LoadStatement can be used as part of another command")
+	}
+
+	return statement.load.writeCommand(command, parameters)
+}
+
+// Err error which occured when preparing the statement
+//
+// **Returns**
+//   - error: error if statement could not get prepared, nil otherwise
+func (statement *PreparedLoadStatement) Err() error {
+	return statement.err
+}
+
 // Execute executes the statement and returns the result rows
 //
 // **Parameters**
@@ -39,38 +72,106 @@ func (statement *PreparedLoadStatement) Command() string {
 //   - Rows: result rows
 //   - error: error if statement could not get executed
 func (statement *PreparedLoadStatement) Execute(arguments ...interface{}) (*sql.Rows, error) {
+	return statement.ExecuteContext(context.Background(), arguments...)
+}
+
+// ExecuteContext executes the statement and returns the result rows
+//
+// **Parameters**
+//   - ctx:       context used to cancel execution
+//   - arguments: arguments used to fill statement parameters
+//
+// **Returns**
+//   - Rows: result rows
+//   - error: error if statement could not get executed
+func (statement *PreparedLoadStatement) ExecuteContext(ctx context.Context, arguments ...interface{}) (*sql.Rows, error) {
+	return statement.query(ctx, statement.connection, arguments...)
+}
+
+// ExecuteTransaction executes the statement and returns the result rows
+//
+// **Parameters**
+//   - arguments: arguments used to fill statement parameters
+//
+// **Returns**
+//   - Rows: result rows
+//   - error: error if statement could not get executed
+func (statement *PreparedLoadStatement) ExecuteTransaction(transaction *sql.Tx, arguments ...interface{}) (*sql.Rows, error) {
+	return statement.ExecuteTransactionContext(context.Background(), transaction, arguments...)
+}
+
+// ExecuteTransactionContext executes the statement and returns the result rows
+//
+// **Parameters**
+//   - ctx:         context used to cancel execution
+//   - transaction: transaction used to execute statement
+//   - arguments:   arguments used to fill statement parameters
+//
+// **Returns**
+//   - Rows: result rows
+//   - error: error if statement could not get executed
+func (statement *PreparedLoadStatement) ExecuteTransactionContext(ctx context.Context, transaction *sql.Tx, arguments ...interface{}) (*sql.Rows, error) {
+	return statement.query(ctx, executor(statement.connection, transaction), arguments...)
+}
+
+// ExecuteExecutorContext executes the statement using an executor like a *sql.DB, *sql.Tx or *sql.Conn and returns the result rows
+//
+// **Parameters**
+//   - ctx:       context used to cancel execution
+//   - executor:  executor used to execute statement
+//   - arguments: arguments used to fill statement parameters
+//
+// **Returns**
+//   - Rows: result rows
+//   - error: error if statement could not get executed
+func (statement *PreparedLoadStatement) ExecuteExecutorContext(ctx context.Context, executor interfaces.IExecutor, arguments ...interface{}) (*sql.Rows, error) {
+	return statement.query(ctx, executor, arguments...)
+}
+
+// query executes the command using an executor. Commands executed using the connection of the statement
+// are prepared once and reused for subsequent executions
+func (statement *PreparedLoadStatement) query(ctx context.Context, executor interfaces.IExecutor, arguments ...interface{}) (*sql.Rows, error) {
+	if statement.err != nil {
+		return nil, statement.err
+	}
+
+	if executor != statement.connection {
+		return executor.QueryContext(ctx, statement.command, arguments...)
+	}
+
 	if statement.prepared == nil {
-		prepared, err := statement.connection.Prepare(statement.command)
+		prepared, err := statement.connection.PrepareContext(ctx, statement.command)
 		if err != nil {
 			return nil, err
 		}
 		statement.prepared = prepared
 	}
-	return statement.prepared.Query(arguments...)
+	return statement.prepared.QueryContext(ctx, arguments...)
 }
 
-// ExecuteTransaction executes the statement and returns the result rows
+// ExecuteSet executes the statement and returns a set of result values. This means the statement should return a set of rows with exactly one column
 //
 // **Parameters**
 //   - arguments: arguments used to fill statement parameters
 //
 // **Returns**
-//   - Rows: result rows
+//   - []interface{}: result set
 //   - error: error if statement could not get executed
-func (statement *PreparedLoadStatement) ExecuteTransaction(transaction *sql.Tx, arguments ...interface{}) (*sql.Rows, error) {
-	return transaction.Query(statement.command, arguments...)
+func (statement *PreparedLoadStatement) ExecuteSet(arguments ...interface{}) ([]interface{}, error) {
+	return statement.ExecuteSetTransactionContext(context.Background(), nil, arguments...)
 }
 
-// ExecuteSet executes the statement and returns a set of result values. This means the statement should return a set of rows with exactly one column
+// ExecuteSetContext executes the statement and returns a set of result values. This means the statement should return a set of rows with exactly one column
 //
 // **Parameters**
+//   - ctx:       context used to cancel execution
 //   - arguments: arguments used to fill statement parameters
 //
 // **Returns**
 //   - []interface{}: result set
 //   - error: error if statement could not get executed
-func (statement *PreparedLoadStatement) ExecuteSet(arguments ...interface{}) ([]interface{}, error) {
-	return statement.ExecuteSetTransaction(nil, arguments...)
+func (statement *PreparedLoadStatement) ExecuteSetContext(ctx context.Context, arguments ...interface{}) ([]interface{}, error) {
+	return statement.ExecuteSetTransactionContext(ctx, nil, arguments...)
 }
 
 // ExecuteSetTransaction executes the statement and returns a set of result values. This means the statement should return a set of rows with exactly one column
@@ -82,15 +183,21 @@ func (statement *PreparedLoadStatement) ExecuteSet(arguments ...interface{}) ([]
 //   - []interface{}: result set
 //   - error: error if statement could not get executed
 func (statement *PreparedLoadStatement) ExecuteSetTransaction(transaction *sql.Tx, arguments ...interface{}) ([]interface{}, error) {
-	var rows *sql.Rows
-	var err error
-
-	if transaction != nil {
-		rows, err = transaction.Query(statement.command, arguments...)
-	} else {
-		rows, err = statement.Execute(arguments...)
-	}
+	return statement.ExecuteSetTransactionContext(context.Background(), transaction, arguments...)
+}
 
+// ExecuteSetTransactionContext executes the statement and returns a set of result values. This means the statement should return a set of rows with exactly one column
+//
+// **Parameters**
+//   - ctx:         context used to cancel execution
+//   - transaction: transaction used to execute statement
+//   - arguments:   arguments used to fill statement parameters
+//
+// **Returns**
+//   - []interface{}: result set
+//   - error: error if statement could not get executed
+func (statement *PreparedLoadStatement) ExecuteSetTransactionContext(ctx context.Context, transaction *sql.Tx, arguments ...interface{}) ([]interface{}, error) {
+	rows, err := statement.query(ctx, executor(statement.connection, transaction), arguments...)
 	if err != nil {
 		return nil, err
 	}
@@ -131,7 +238,21 @@ func (statement *PreparedLoadStatement) ExecuteSetTransaction(transaction *sql.T
 //   - interface{}: result scalar
 //   - error: error if statement could not get executed
 func (statement *PreparedLoadStatement) ExecuteScalar(arguments ...interface{}) (interface{}, error) {
-	return statement.ExecuteScalarTransaction(nil, arguments...)
+	return statement.ExecuteScalarTransactionContext(context.Background(), nil, arguments...)
+}
+
+// ExecuteScalarContext executes the statement and returns one value as result. This means the statement should return exactly one column.
+//                      Multiple rows are supported however.
+//
+// **Parameters**
+//   - ctx:       context used to cancel execution
+//   - arguments: arguments used to fill statement parameters
+//
+// **Returns**
+//   - interface{}: result scalar
+//   - error: error if statement could not get executed
+func (statement *PreparedLoadStatement) ExecuteScalarContext(ctx context.Context, arguments ...interface{}) (interface{}, error) {
+	return statement.ExecuteScalarTransactionContext(ctx, nil, arguments...)
 }
 
 // ExecuteScalarTransaction executes the statement and returns one value as result. This means the statement should return exactly one column.
@@ -144,15 +265,22 @@ func (statement *PreparedLoadStatement) ExecuteScalar(arguments ...interface{})
 //   - interface{}: result scalar
 //   - error: error if statement could not get executed
 func (statement *PreparedLoadStatement) ExecuteScalarTransaction(transaction *sql.Tx, arguments ...interface{}) (interface{}, error) {
-	var rows *sql.Rows
-	var err error
-
-	if transaction != nil {
-		rows, err = transaction.Query(statement.command, arguments...)
-	} else {
-		rows, err = statement.Execute(arguments...)
-	}
+	return statement.ExecuteScalarTransactionContext(context.Background(), transaction, arguments...)
+}
 
+// ExecuteScalarTransactionContext executes the statement and returns one value as result. This means the statement should return exactly one column.
+//                                 Multiple rows are supported however.
+//
+// **Parameters**
+//   - ctx:         context used to cancel execution
+//   - transaction: transaction used to execute statement
+//   - arguments:   arguments used to fill statement parameters
+//
+// **Returns**
+//   - interface{}: result scalar
+//   - error: error if statement could not get executed
+func (statement *PreparedLoadStatement) ExecuteScalarTransactionContext(ctx context.Context, transaction *sql.Tx, arguments ...interface{}) (interface{}, error) {
+	rows, err := statement.query(ctx, executor(statement.connection, transaction), arguments...)
 	if err != nil {
 		return nil, err
 	}
@@ -183,69 +311,173 @@ func (statement *PreparedLoadStatement) ExecuteScalarTransaction(transaction *sq
 
 // ExecuteEntity - loads matching entity data from database
 func (statement *PreparedLoadStatement) ExecuteEntity(arguments ...interface{}) ([]interface{}, error) {
-	return statement.ExecuteEntityTransaction(nil, arguments...)
+	return statement.ExecuteMappedEntityTransactionContext(context.Background(), nil, statement.model, arguments...)
+}
+
+// ExecuteEntityContext - loads matching entity data from database
+func (statement *PreparedLoadStatement) ExecuteEntityContext(ctx context.Context, arguments ...interface{}) ([]interface{}, error) {
+	return statement.ExecuteMappedEntityTransactionContext(ctx, nil, statement.model, arguments...)
 }
 
 // ExecuteEntityTransaction - loads matching entity data from database
 func (statement *PreparedLoadStatement) ExecuteEntityTransaction(transaction *sql.Tx, arguments ...interface{}) ([]interface{}, error) {
-	return statement.ExecuteMappedEntityTransaction(transaction, statement.model, arguments...)
+	return statement.ExecuteMappedEntityTransactionContext(context.Background(), transaction, statement.model, arguments...)
+}
+
+// ExecuteEntityTransactionContext - loads matching entity data from database
+func (statement *PreparedLoadStatement) ExecuteEntityTransactionContext(ctx context.Context, transaction *sql.Tx, arguments ...interface{}) ([]interface{}, error) {
+	return statement.ExecuteMappedEntityTransactionContext(ctx, transaction, statement.model, arguments...)
 }
 
-// ExecuteMappedEntity - loads matching entity data from database
+// ExecuteMappedEntity - loads matching entity data from database. Result columns are mapped to fields by column name
+//                       or by field name, so expressions named using xpr.As can be mapped to fields of any struct model
 func (statement *PreparedLoadStatement) ExecuteMappedEntity(model *models.EntityModel, arguments ...interface{}) ([]interface{}, error) {
-	return statement.ExecuteMappedEntityTransaction(nil, model, arguments...)
+	return statement.ExecuteMappedEntityTransactionContext(context.Background(), nil, model, arguments...)
+}
+
+// ExecuteMappedEntityContext - loads matching entity data from database
+func (statement *PreparedLoadStatement) ExecuteMappedEntityContext(ctx context.Context, model *models.EntityModel, arguments ...interface{}) ([]interface{}, error) {
+	return statement.ExecuteMappedEntityTransactionContext(ctx, nil, model, arguments...)
 }
 
 // ExecuteMappedEntityTransaction - loads matching entity data from database
 func (statement *PreparedLoadStatement) ExecuteMappedEntityTransaction(transaction *sql.Tx, model *models.EntityModel, arguments ...interface{}) ([]interface{}, error) {
-	var rows *sql.Rows
-	var err error
-
-	if transaction != nil {
-		rows, err = transaction.Query(statement.command, arguments...)
-	} else {
-		rows, err = statement.Execute(arguments...)
-	}
+	return statement.ExecuteMappedEntityTransactionContext(context.Background(), transaction, model, arguments...)
+}
 
+// ExecuteMappedEntityTransactionContext - loads matching entity data from database
+func (statement *PreparedLoadStatement) ExecuteMappedEntityTransactionContext(ctx context.Context, transaction *sql.Tx, model *models.EntityModel, arguments ...interface{}) ([]interface{}, error) {
+	rows, err := statement.query(ctx, executor(statement.connection, transaction), arguments...)
 	if err != nil {
 		return nil, err
 	}
 
 	defer rows.Close()
 
-	columns, err := rows.Columns()
+	return mapEntities(rows, model, statement.unmapped, 0)
+}
+
+// ExecuteCursor executes the statement and returns a cursor mapping result rows to entities one at a time
+//
+// **Parameters**
+//   - arguments: arguments used to fill statement parameters
+//
+// **Returns**
+//   - *EntityCursor: cursor to iterate over entities, has to get closed by the caller
+//   - error:         error if statement could not get executed
+func (statement *PreparedLoadStatement) ExecuteCursor(arguments ...interface{}) (*EntityCursor, error) {
+	return statement.ExecuteMappedCursorContext(context.Background(), statement.model, arguments...)
+}
+
+// ExecuteCursorContext executes the statement and returns a cursor mapping result rows to entities one at a time
+//
+// **Parameters**
+//   - ctx:       context used to cancel execution
+//   - arguments: arguments used to fill statement parameters
+//
+// **Returns**
+//   - *EntityCursor: cursor to iterate over entities, has to get closed by the caller
+//   - error:         error if statement could not get executed
+func (statement *PreparedLoadStatement) ExecuteCursorContext(ctx context.Context, arguments ...interface{}) (*EntityCursor, error) {
+	return statement.ExecuteMappedCursorContext(ctx, statement.model, arguments...)
+}
+
+// ExecuteMappedCursorContext executes the statement and returns a cursor mapping result rows to entities
+//                            of a custom model one at a time
+//
+// **Parameters**
+//   - ctx:       context used to cancel execution
+//   - model:     model of entities to map result rows to
+//   - arguments: arguments used to fill statement parameters
+//
+// **Returns**
+//   - *EntityCursor: cursor to iterate over entities, has to get closed by the caller
+//   - error:         error if statement could not get executed
+func (statement *PreparedLoadStatement) ExecuteMappedCursorContext(ctx context.Context, model *models.EntityModel, arguments ...interface{}) (*EntityCursor, error) {
+	rows, err := statement.query(ctx, statement.connection, arguments...)
 	if err != nil {
 		return nil, err
 	}
 
-	var setters []reflect.StructField = make([]reflect.StructField, len(columns))
-	for index, column := range columns {
-		columndescription := model.Column(column)
-		field, ok := model.EntityType().FieldByName(columndescription.Field())
-		if !ok {
-			continue
-		}
+	mapper, err := newEntityMapper(rows, model, statement.unmapped)
+	if err != nil {
+		rows.Close()
+		return nil, err
+	}
+
+	return &EntityCursor{
+		rows:   rows,
+		mapper: mapper}, nil
+}
+
+// ForEach executes the statement and calls a function for every entity mapped from the result rows
+//
+// **Parameters**
+//   - function:  function to call for every entity, iteration stops if it returns an error
+//   - arguments: arguments used to fill statement parameters
+//
+// **Returns**
+//   - error: error returned by function or error if statement could not get executed
+func (statement *PreparedLoadStatement) ForEach(function func(entity interface{}) error, arguments ...interface{}) error {
+	return statement.ForEachContext(context.Background(), function, arguments...)
+}
 
-		setters[index] = field
+// ForEachContext executes the statement and calls a function for every entity mapped from the result rows
+//
+// **Parameters**
+//   - ctx:       context used to cancel execution
+//   - function:  function to call for every entity, iteration stops if it returns an error
+//   - arguments: arguments used to fill statement parameters
+//
+// **Returns**
+//   - error: error returned by function or error if statement could not get executed
+func (statement *PreparedLoadStatement) ForEachContext(ctx context.Context, function func(entity interface{}) error, arguments ...interface{}) error {
+	cursor, err := statement.ExecuteCursorContext(ctx, arguments...)
+	if err != nil {
+		return err
 	}
 
-	var values []interface{} = make([]interface{}, len(columns))
-	var entities []interface{}
-	for rows.Next() {
-		entity := reflect.New(model.EntityType())
+	defer cursor.Close()
 
-		for index, ptr := range setters {
-			values[index] = reflect.NewAt(ptr.Type, unsafe.Pointer(entity.Pointer()+ptr.Offset)).Interface()
+	for cursor.Next() {
+		err = function(cursor.Entity())
+		if err != nil {
+			return err
 		}
+	}
+
+	return cursor.Err()
+}
+
+// mapEntities maps result rows to entities of a model
+//
+// **Parameters**
+//   - rows:     result rows to map
+//   - model:    model of entities to create
+//   - unmapped: determines how result columns are handled which are not mapped to a field
+//   - limit:    maximum number of entities to map, 0 to map all rows
+//
+// **Returns**
+//   - []interface{}: pointers to mapped entities
+//   - error:         error if result rows could not get read or mapped
+func mapEntities(rows *sql.Rows, model *models.EntityModel, unmapped UnmappedColumns, limit int) ([]interface{}, error) {
+	mapper, err := newEntityMapper(rows, model, unmapped)
+	if err != nil {
+		return nil, err
+	}
 
-		err := rows.Scan(values...)
+	var entities []interface{}
+	for rows.Next() {
+		entity, err := mapper.scan(rows)
 		if err != nil {
-			log.Printf("ERR: %s", err.Error())
-			continue
+			return nil, err
 		}
 
-		entities = append(entities, entity.Interface())
+		entities = append(entities, entity)
+		if limit > 0 && len(entities) >= limit {
+			break
+		}
 	}
 
-	return entities, nil
+	return entities, rows.Err()
 }
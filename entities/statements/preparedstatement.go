@@ -1,17 +1,21 @@
 package statements
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
+
+	"github.com/verticalgmbh/database-go/interfaces"
 )
 
 // PreparedStatement - statement containing a prepared command to be executed
 type PreparedStatement struct {
-	command    string
-	connection *sql.DB
-	loadresult bool
-	postquery  string
+	command      string
+	connection   interfaces.IExecutor
+	loadresult   bool
+	lastinsertid bool  // returns the last insert id provided by the execution result instead of the number of affected rows
+	err          error // error which occured when building the command
 
 	prepared *sql.Stmt
 }
@@ -24,6 +28,14 @@ func (statement *PreparedStatement) Command() string {
 	return statement.command
 }
 
+// Err error which occured when preparing the statement
+//
+// **Returns**
+//   - error: error if statement could not get prepared, nil otherwise
+func (statement *PreparedStatement) Err() error {
+	return statement.err
+}
+
 // Execute executes the statement
 //
 // **Parameters**
@@ -33,14 +45,20 @@ func (statement *PreparedStatement) Command() string {
 //   - int64: number of affected rows
 //   - error: error if any occured
 func (statement *PreparedStatement) Execute(arguments ...interface{}) (int64, error) {
-	if statement.prepared == nil {
-		prepared, err := statement.connection.Prepare(statement.command)
-		if err != nil {
-			return 0, err
-		}
-		statement.prepared = prepared
-	}
-	return statement.ExecuteTransaction(nil, arguments...)
+	return statement.ExecuteContext(context.Background(), arguments...)
+}
+
+// ExecuteContext executes the statement
+//
+// **Parameters**
+//   - ctx:       context used to cancel execution
+//   - arguments: parameter values for statement
+//
+// **Returns**
+//   - int64: number of affected rows
+//   - error: error if any occured
+func (statement *PreparedStatement) ExecuteContext(ctx context.Context, arguments ...interface{}) (int64, error) {
+	return statement.ExecuteExecutorContext(ctx, statement.connection, arguments...)
 }
 
 // ExecuteTransaction executes the statement using a transaction
@@ -53,66 +71,88 @@ func (statement *PreparedStatement) Execute(arguments ...interface{}) (int64, er
 //   - int64: number of affected rows
 //   - error: error if any occured
 func (statement *PreparedStatement) ExecuteTransaction(transaction *sql.Tx, arguments ...interface{}) (int64, error) {
-	var result sql.Result
-	var err error
+	return statement.ExecuteTransactionContext(context.Background(), transaction, arguments...)
+}
 
-	if statement.loadresult {
-		var rows *sql.Rows
-		if transaction != nil {
-			rows, err = transaction.Query(statement.command, arguments...)
-		} else {
-			rows, err = statement.connection.Query(statement.command, arguments...)
-		}
+// ExecuteTransactionContext executes the statement using a transaction
+//
+// **Parameters**
+//   - ctx:         context used to cancel execution
+//   - transaction: transaction used to execute statement
+//   - arguments:   parameter values for statement
+//
+// **Returns**
+//   - int64: number of affected rows
+//   - error: error if any occured
+func (statement *PreparedStatement) ExecuteTransactionContext(ctx context.Context, transaction *sql.Tx, arguments ...interface{}) (int64, error) {
+	return statement.ExecuteExecutorContext(ctx, executor(statement.connection, transaction), arguments...)
+}
 
+// ExecuteExecutorContext executes the statement using an executor like a *sql.DB, *sql.Tx or *sql.Conn
+//
+// **Parameters**
+//   - ctx:       context used to cancel execution
+//   - executor:  executor used to execute statement
+//   - arguments: parameter values for statement
+//
+// **Returns**
+//   - int64: number of affected rows
+//   - error: error if any occured
+func (statement *PreparedStatement) ExecuteExecutorContext(ctx context.Context, executor interfaces.IExecutor, arguments ...interface{}) (int64, error) {
+	if statement.err != nil {
+		return 0, statement.err
+	}
+
+	if statement.loadresult {
+		rows, err := executor.QueryContext(ctx, statement.command, arguments...)
 		if err != nil {
 			return 0, err
 		}
 		defer rows.Close()
 
-		for rows.Next() {
-			var value int64
-			err := rows.Scan(&value)
-			if err != nil {
-				return 0, err
-			}
-
-			return value, nil
-		}
+		return scanInt64(rows)
+	}
 
-		return 0, errors.New("No result rows where returned by the statement")
+	result, err := statement.exec(ctx, executor, arguments...)
+	if err != nil {
+		return 0, fmt.Errorf("Error executing '%s': %w", statement.command, err)
 	}
 
-	if transaction != nil {
-		result, err = transaction.Exec(statement.command, arguments...)
-	} else {
-		result, err = statement.prepared.Exec(arguments...)
+	if statement.lastinsertid {
+		// provided by the driver for the connection which executed the command
+		return result.LastInsertId()
 	}
 
-	if err != nil {
-		return 0, fmt.Errorf("Error executing '%s': %s", statement.command, err.Error())
+	return result.RowsAffected()
+}
+
+// exec executes the command using an executor. Commands executed using the connection of the statement
+// are prepared once and reused for subsequent executions
+func (statement *PreparedStatement) exec(ctx context.Context, executor interfaces.IExecutor, arguments ...interface{}) (sql.Result, error) {
+	if executor != statement.connection {
+		return executor.ExecContext(ctx, statement.command, arguments...)
 	}
 
-	if len(statement.postquery) == 0 {
-		affected, err := result.RowsAffected()
+	if statement.prepared == nil {
+		prepared, err := statement.connection.PrepareContext(ctx, statement.command)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		return affected, nil
+		statement.prepared = prepared
 	}
+	return statement.prepared.ExecContext(ctx, arguments...)
+}
 
-	// postquery is used by sqlite to load last row id
-	var rows *sql.Rows
+// executor provides the transaction if one is specified or the connection otherwise
+func executor(connection interfaces.IExecutor, transaction *sql.Tx) interfaces.IExecutor {
 	if transaction != nil {
-		rows, err = transaction.Query(statement.postquery)
-	} else {
-		rows, err = statement.connection.Query(statement.postquery)
+		return transaction
 	}
+	return connection
+}
 
-	if err != nil {
-		return 0, err
-	}
-	defer rows.Close()
-
+// scanInt64 scans the first column of the first result row as int64
+func scanInt64(rows *sql.Rows) (int64, error) {
 	for rows.Next() {
 		var value int64
 		err := rows.Scan(&value)
@@ -0,0 +1,120 @@
+package statements
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+
+	"github.com/verticalgmbh/database-go/entities/models"
+)
+
+// Query executes a load statement and maps the result rows to entities of type T. T is either
+//       a struct type or a pointer to a struct type.
+//
+// **Parameters**
+//   - statement: statement to execute
+//   - arguments: arguments used to fill statement parameters
+//
+// **Returns**
+//   - []T:   loaded entities
+//   - error: error if statement could not get executed
+func Query[T any](statement *PreparedLoadStatement, arguments ...interface{}) ([]T, error) {
+	return QueryContext[T](context.Background(), statement, arguments...)
+}
+
+// QueryContext executes a load statement and maps the result rows to entities of type T. T is either
+//              a struct type or a pointer to a struct type.
+//
+// **Parameters**
+//   - ctx:       context used to cancel execution
+//   - statement: statement to execute
+//   - arguments: arguments used to fill statement parameters
+//
+// **Returns**
+//   - []T:   loaded entities
+//   - error: error if statement could not get executed
+func QueryContext[T any](ctx context.Context, statement *PreparedLoadStatement, arguments ...interface{}) ([]T, error) {
+	return queryTyped[T](ctx, statement, 0, arguments...)
+}
+
+// First executes a load statement and maps the first result row to an entity of type T. T is either
+//       a struct type or a pointer to a struct type.
+//
+// **Parameters**
+//   - statement: statement to execute
+//   - arguments: arguments used to fill statement parameters
+//
+// **Returns**
+//   - T:     loaded entity
+//   - error: sql.ErrNoRows if statement returned no rows, error if statement could not get executed
+func First[T any](statement *PreparedLoadStatement, arguments ...interface{}) (T, error) {
+	return FirstContext[T](context.Background(), statement, arguments...)
+}
+
+// FirstContext executes a load statement and maps the first result row to an entity of type T. T is either
+//              a struct type or a pointer to a struct type.
+//
+// **Parameters**
+//   - ctx:       context used to cancel execution
+//   - statement: statement to execute
+//   - arguments: arguments used to fill statement parameters
+//
+// **Returns**
+//   - T:     loaded entity
+//   - error: sql.ErrNoRows if statement returned no rows, error if statement could not get executed
+func FirstContext[T any](ctx context.Context, statement *PreparedLoadStatement, arguments ...interface{}) (T, error) {
+	var result T
+
+	entities, err := queryTyped[T](ctx, statement, 1, arguments...)
+	if err != nil {
+		return result, err
+	}
+
+	if len(entities) == 0 {
+		return result, sql.ErrNoRows
+	}
+
+	return entities[0], nil
+}
+
+// queryTyped executes a load statement and maps at most limit result rows to entities of type T
+func queryTyped[T any](ctx context.Context, statement *PreparedLoadStatement, limit int, arguments ...interface{}) ([]T, error) {
+	entitytype := reflect.TypeOf((*T)(nil)).Elem()
+	pointer := entitytype.Kind() == reflect.Ptr
+	if pointer {
+		entitytype = entitytype.Elem()
+	}
+
+	if entitytype.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("Entity type has to be a struct or a pointer to a struct but is %s", entitytype)
+	}
+
+	model := statement.model
+	if model == nil || model.EntityType() != entitytype {
+		model = models.CreateModel(entitytype)
+	}
+
+	rows, err := statement.query(ctx, statement.connection, arguments...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	entities, err := mapEntities(rows, model, statement.unmapped, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]T, len(entities))
+	for index, entity := range entities {
+		if pointer {
+			result[index] = entity.(T)
+		} else {
+			result[index] = *entity.(*T)
+		}
+	}
+
+	return result, nil
+}
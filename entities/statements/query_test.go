@@ -0,0 +1,65 @@
+package statements
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/verticalgmbh/database-go/connection"
+	"github.com/verticalgmbh/database-go/entities/models"
+	"github.com/verticalgmbh/database-go/xpr"
+)
+
+type QueryEntity struct {
+	ID   int64 `database:"primarykey,autoincrement"`
+	Name string
+}
+
+func createQueryDatabase(t *testing.T) (*sql.DB, *models.Model[QueryEntity]) {
+	database, _ := sql.Open("sqlite3", ":memory:")
+	database.SetMaxOpenConns(1)
+
+	model := models.NewModel[QueryEntity]()
+	_, err := NewCreateStatement(model.EntityModel, database, &connection.SqliteInfo{}).Prepare().Execute()
+	require.NoError(t, err)
+
+	_, err = NewInsertStatement(model.EntityModel, database, &connection.SqliteInfo{}).Columns("Name").PrepareBatch().Execute([]interface{}{"first"}, []interface{}{"second"})
+	require.NoError(t, err)
+
+	return database, model
+}
+
+func TestQueryTyped(t *testing.T) {
+	database, model := createQueryDatabase(t)
+	defer database.Close()
+
+	require.Equal(t, "queryentity", model.Table)
+
+	statement := NewLoadStatement(database, &connection.SqliteInfo{}).Model(model.EntityModel).OrderBy(xpr.Desc(xpr.Field(model.EntityModel, "ID"))).Prepare()
+
+	entities, err := Query[QueryEntity](statement)
+	require.NoError(t, err)
+	require.Equal(t, []QueryEntity{{ID: 2, Name: "second"}, {ID: 1, Name: "first"}}, entities)
+
+	pointers, err := Query[*QueryEntity](statement)
+	require.NoError(t, err)
+	require.Equal(t, []*QueryEntity{{ID: 2, Name: "second"}, {ID: 1, Name: "first"}}, pointers)
+
+	_, err = Query[string](statement)
+	require.Error(t, err)
+}
+
+func TestFirstTyped(t *testing.T) {
+	database, model := createQueryDatabase(t)
+	defer database.Close()
+
+	statement := NewLoadStatement(database, &connection.SqliteInfo{}).Model(model.EntityModel).Where(xpr.Equals(xpr.Field(model.EntityModel, "Name"), xpr.Parameter())).Prepare()
+
+	entity, err := First[QueryEntity](statement, "second")
+	require.NoError(t, err)
+	require.Equal(t, QueryEntity{ID: 2, Name: "second"}, entity)
+
+	_, err = First[*QueryEntity](statement, "third")
+	require.Equal(t, sql.ErrNoRows, err)
+}
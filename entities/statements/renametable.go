@@ -1,15 +1,15 @@
 package statements
 
 import (
-	"database/sql"
 	"strings"
 
 	"github.com/verticalgmbh/database-go/connection"
+	"github.com/verticalgmbh/database-go/interfaces"
 )
 
 // RenameTable statement used to rename a table in a database
 type RenameTable struct {
-	connection     *sql.DB
+	connection     interfaces.IExecutor
 	connectioninfo connection.IConnectionInfo
 	oldname        string
 	newname        string
@@ -25,7 +25,7 @@ type RenameTable struct {
 //
 // **Returns**
 //   - *RenameTable: statement to use to prepare operation
-func NewRenameTable(connection *sql.DB, connectioninfo connection.IConnectionInfo, oldname string, newname string) *RenameTable {
+func NewRenameTable(connection interfaces.IExecutor, connectioninfo connection.IConnectionInfo, oldname string, newname string) *RenameTable {
 	return &RenameTable{
 		connection:     connection,
 		connectioninfo: connectioninfo,
@@ -36,10 +36,7 @@ func NewRenameTable(connection *sql.DB, connectioninfo connection.IConnectionInf
 func (statement *RenameTable) buildCommandText() string {
 	var command strings.Builder
 
-	command.WriteString("ALTER TABLE ")
-	command.WriteString(statement.oldname)
-	command.WriteString(" RENAME TO ")
-	command.WriteString(statement.newname)
+	statement.connectioninfo.RenameTable(statement.oldname, statement.newname, &command)
 
 	return command.String()
 }
@@ -0,0 +1,33 @@
+package statements
+
+import (
+	"strings"
+
+	"github.com/verticalgmbh/database-go/connection"
+	"github.com/verticalgmbh/database-go/entities/models"
+	"github.com/verticalgmbh/database-go/entities/walkers"
+	"github.com/verticalgmbh/database-go/xpr"
+)
+
+// returningFields provides fields to return from a data modification command, all columns of the model if no fields are specified
+func returningFields(model *models.EntityModel, fields []interface{}) []interface{} {
+	if len(fields) > 0 {
+		return fields
+	}
+
+	var result []interface{}
+	for _, column := range model.Columns() {
+		result = append(result, xpr.Field(model, column.Field()))
+	}
+	return result
+}
+
+// writeReturning writes returned fields at a position of a data modification command
+func writeReturning(connectioninfo connection.IConnectionInfo, position connection.ReturningPosition, deleted bool, fields []interface{}, command *strings.Builder, parameters *connection.Parameters) error {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	sqlwalker := walkers.NewSqlWalker(connectioninfo, command, parameters)
+	return connectioninfo.EvaluateReturning(position, deleted, fields, command, sqlwalker.Visit)
+}
@@ -0,0 +1,48 @@
+package statements
+
+import (
+	"strings"
+
+	"github.com/verticalgmbh/database-go/connection"
+	"github.com/verticalgmbh/database-go/interfaces"
+)
+
+// Savepoint statement to create, release or roll back a savepoint of a transaction
+type Savepoint struct {
+	connection     interfaces.IExecutor
+	connectioninfo connection.IConnectionInfo
+	operation      connection.SavepointOperation
+	name           string
+}
+
+// NewSavepoint creates a new Savepoint statement
+//
+// **Parameters**
+//   - connection:     transaction to use to execute statement
+//   - connectioninfo: driver specific connection info
+//   - operation:      operation to execute on savepoint
+//   - name:           name of savepoint
+//
+// **Returns**
+//   - *Savepoint: statement to use to prepare operation
+func NewSavepoint(connection interfaces.IExecutor, connectioninfo connection.IConnectionInfo, operation connection.SavepointOperation, name string) *Savepoint {
+	return &Savepoint{
+		connection:     connection,
+		connectioninfo: connectioninfo,
+		operation:      operation,
+		name:           name}
+}
+
+// Prepare prepares the statement for execution. The command is empty if the operation is not necessary in database.
+//
+// **Returns**
+//   - *PreparedStatement: prepared operation to execute
+func (statement *Savepoint) Prepare() *PreparedStatement {
+	var command strings.Builder
+	err := statement.connectioninfo.EvaluateSavepoint(statement.operation, statement.name, &command)
+
+	return &PreparedStatement{
+		connection: statement.connection,
+		command:    command.String(),
+		err:        err}
+}
@@ -0,0 +1,17 @@
+package statements
+
+// setoperator operator used to combine result sets
+type setoperator int8
+
+const (
+	setoperatorUnion setoperator = iota
+	setoperatorUnionAll
+	setoperatorIntersect
+	setoperatorExcept
+)
+
+// setoperation combines the result of a load statement with the result of another statement
+type setoperation struct {
+	operator  setoperator
+	statement interface{} // *LoadStatement or *PreparedLoadStatement providing the rows to combine
+}
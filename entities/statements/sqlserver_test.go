@@ -0,0 +1,275 @@
+package statements
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/verticalgmbh/database-go/connection"
+	"github.com/verticalgmbh/database-go/entities/models"
+	"github.com/verticalgmbh/database-go/xpr"
+)
+
+func TestSQLServerLoad(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	statement := NewLoadStatement(nil, connection.NewSQLServerInfo()).Model(model)
+	statement.Where(xpr.And(xpr.Equals(xpr.Field(model, "Name"), xpr.NamedParameter("name")), xpr.Grt(xpr.Field(model, "Counter"), xpr.Parameter())))
+
+	require.Equal(t, `SELECT [id],[name],[counter],[active],[created],[data] FROM dialectentity WHERE [name] = @p1 AND [counter] > @p2`, statement.Prepare().Command())
+}
+
+func TestSQLServerRepeatedNamedParameter(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	statement := NewLoadStatement(nil, connection.NewSQLServerInfo()).Model(model)
+	statement.Where(xpr.And(xpr.And(xpr.Grt(xpr.Field(model, "Counter"), xpr.Parameter()), xpr.Equals(xpr.Field(model, "Name"), xpr.NamedParameter("name"))), xpr.EqualsNot(xpr.Field(model, "Data"), xpr.NamedParameter("name"))))
+
+	require.Equal(t, `SELECT [id],[name],[counter],[active],[created],[data] FROM dialectentity WHERE [counter] > @p1 AND [name] = @p2 AND [data] <> @p2`, statement.Prepare().Command())
+}
+
+func TestSQLServerLoadJoinGroupUnion(t *testing.T) {
+	info := connection.NewSQLServerInfo()
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	union := NewLoadStatement(nil, info).Table("archive").Fields(xpr.Column("name"), xpr.Max(xpr.Column("counter")))
+
+	statement := NewLoadStatement(nil, info).Table(model.Table).Alias("e")
+	statement.Fields(xpr.AliasField("e", model, "Name"), xpr.Max(xpr.AliasField("o", model, "Counter")))
+	statement.Join(JoinTypeInner, "other", xpr.Equals(xpr.AliasField("o", model, "ID"), xpr.AliasField("e", model, "ID")), "o")
+	statement.GroupBy(xpr.AliasField("e", model, "Name"))
+	statement.Union(union.Prepare(), false)
+
+	require.Equal(t, `SELECT e.[name],MAX(o.[counter]) FROM dialectentity AS e INNER JOIN other AS o ON o.[id] = e.[id] GROUP BY e.[name] UNION SELECT [name],MAX([counter]) FROM archive`, statement.Prepare().Command())
+}
+
+func TestSQLServerLoadMixedSetOperations(t *testing.T) {
+	info := connection.NewSQLServerInfo()
+
+	archive := NewLoadStatement(nil, info).Table("archive").Fields(xpr.Column("name"))
+	active := NewLoadStatement(nil, info).Table("active").Fields(xpr.Column("name"))
+	deleted := NewLoadStatement(nil, info).Table("deleted").Fields(xpr.Column("name"))
+
+	statement := NewLoadStatement(nil, info).Table("dialectentity").Fields(xpr.Column("name"))
+	statement.Except(archive).Intersect(active).UnionAll(deleted).Intersect(archive).Limit(10)
+
+	operation := statement.Prepare()
+
+	require.NoError(t, operation.Err())
+	require.Equal(t, `SELECT * FROM (SELECT * FROM (SELECT [name] FROM dialectentity EXCEPT SELECT [name] FROM archive) AS combined1 INTERSECT SELECT [name] FROM active UNION ALL SELECT [name] FROM deleted) AS combined3 INTERSECT SELECT [name] FROM archive ORDER BY (SELECT NULL) OFFSET 0 ROWS FETCH NEXT 10 ROWS ONLY`, operation.Command())
+}
+
+func TestSQLServerLoadSetOperandOrderBy(t *testing.T) {
+	info := connection.NewSQLServerInfo()
+
+	archive := NewLoadStatement(nil, info).Table("archive").Fields(xpr.Column("name")).OrderBy(xpr.Column("name"))
+	statement := NewLoadStatement(nil, info).Table("dialectentity").Fields(xpr.Column("name")).Intersect(archive.Prepare())
+
+	require.Error(t, statement.Prepare().Err())
+}
+
+func TestSQLServerInsert(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	statement := NewInsertStatement(model, nil, connection.NewSQLServerInfo()).Columns("Name", "Counter", "Created")
+
+	require.Equal(t, `INSERT INTO dialectentity ([name],[counter],[created]) VALUES(@p1,@p2,@p3)`, statement.Prepare().Command())
+}
+
+func TestSQLServerInsertReturnID(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	statement := NewInsertStatement(model, nil, connection.NewSQLServerInfo()).Columns("Name", "Counter").ReturnID()
+	operation := statement.Prepare()
+
+	require.Equal(t, `INSERT INTO dialectentity ([name],[counter]) VALUES(@p1,@p2);SELECT CAST(SCOPE_IDENTITY() AS BIGINT)`, operation.Command())
+	require.True(t, operation.loadresult)
+	require.False(t, operation.lastinsertid)
+}
+
+func TestSQLServerInsertLoad(t *testing.T) {
+	info := connection.NewSQLServerInfo()
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	load := NewLoadStatement(nil, info).Table("archive").Fields(xpr.Column("name"), xpr.Column("counter")).Where(xpr.Equals(xpr.Column("active"), 1))
+	statement := NewInsertLoad(model, nil, info).Fields("Name", "Counter").Load(load)
+
+	require.Equal(t, `INSERT INTO dialectentity ([name],[counter]) SELECT [name],[counter] FROM archive WHERE [active] = 1`, statement.Prepare().Command())
+}
+
+func TestSQLServerUpdate(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	statement := NewUpdateStatement(model, nil, connection.NewSQLServerInfo())
+	statement.Set(xpr.Assign(xpr.Field(model, "Counter"), xpr.Add(xpr.Field(model, "Counter"), xpr.Parameter())))
+	statement.Where(xpr.Equals(xpr.Field(model, "ID"), xpr.Parameter()))
+
+	require.Equal(t, `UPDATE dialectentity SET [counter] = [counter] + @p1 WHERE [id] = @p2`, statement.Prepare().Command())
+}
+
+func TestSQLServerDelete(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	statement := NewDeleteStatement(model, nil, connection.NewSQLServerInfo())
+	statement.Where(xpr.Equals(xpr.Field(model, "ID"), xpr.Parameter()))
+
+	require.Equal(t, `DELETE FROM dialectentity WHERE [id] = @p1`, statement.Prepare().Command())
+}
+
+func TestSQLServerCreate(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	statement := NewCreateStatement(model, nil, connection.NewSQLServerInfo())
+
+	require.Equal(t, `CREATE TABLE dialectentity ([id] BIGINT IDENTITY(1,1) PRIMARY KEY,[name] NVARCHAR(450) UNIQUE NOT NULL,[counter] INT,[active] BIT DEFAULT 1,[created] DATETIME2,[data] VARBINARY(MAX))`, statement.Prepare().Command())
+}
+
+func TestSQLServerCreateIndex(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	statement := NewCreateIndexStatement(model, model.Indices()[0], nil, connection.NewSQLServerInfo())
+
+	require.Equal(t, "DROP INDEX IF EXISTS idx_dialectentity_counter ON dialectentity;\nCREATE INDEX idx_dialectentity_counter ON dialectentity ([counter]);", statement.Prepare().Command())
+}
+
+func TestSQLServerAddColumn(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	statement := NewAddColumnStatement(nil, connection.NewSQLServerInfo(), model, model.ColumnFromField("Created"))
+
+	require.Equal(t, `ALTER TABLE dialectentity ADD [created] DATETIME2`, statement.Prepare().Command())
+}
+
+func TestSQLServerAddColumnBooleanDefault(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	statement := NewAddColumnStatement(nil, connection.NewSQLServerInfo(), model, model.ColumnFromField("Active"))
+
+	require.Equal(t, `ALTER TABLE dialectentity ADD [active] BIT DEFAULT 1`, statement.Prepare().Command())
+}
+
+func TestSQLServerAddUnique(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	statement := NewAddUnique(nil, connection.NewSQLServerInfo(), model, models.NewIndexDescriptor("", "name", "counter"))
+
+	require.Equal(t, `ALTER TABLE dialectentity ADD UNIQUE([name],[counter])`, statement.Prepare().Command())
+}
+
+func TestSQLServerDropIndex(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	statement := NewDropIndex(nil, connection.NewSQLServerInfo(), model, "idx_dialectentity_counter")
+
+	require.Equal(t, `DROP INDEX idx_dialectentity_counter ON dialectentity`, statement.Prepare().Command())
+}
+
+func TestSQLServerDropTable(t *testing.T) {
+	statement := NewDropTable(nil, connection.NewSQLServerInfo(), "dialectentity")
+
+	require.Equal(t, `DROP TABLE dialectentity`, statement.Prepare().Command())
+}
+
+func TestSQLServerRenameTable(t *testing.T) {
+	statement := NewRenameTable(nil, connection.NewSQLServerInfo(), "dialectentity", "dialectentity_original")
+
+	require.Equal(t, `EXEC sp_rename 'dialectentity', 'dialectentity_original'`, statement.Prepare().Command())
+}
+
+func TestSQLServerLoadLimitOffset(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	statement := NewLoadStatement(nil, connection.NewSQLServerInfo()).Table(model.Table).Fields(xpr.Field(model, "Name"))
+	statement.Limit(xpr.NamedParameter("limit")).Offset(xpr.NamedParameter("offset"))
+
+	// the limit is bound before the offset like in all other databases
+	require.Equal(t, `SELECT [name] FROM dialectentity ORDER BY (SELECT NULL) OFFSET @p2 ROWS FETCH NEXT @p1 ROWS ONLY`, statement.Prepare().Command())
+}
+
+func TestSQLServerLoadLimit(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	statement := NewLoadStatement(nil, connection.NewSQLServerInfo()).Table(model.Table).Fields(xpr.Field(model, "Name"))
+	statement.Limit(5)
+
+	require.Equal(t, `SELECT [name] FROM dialectentity ORDER BY (SELECT NULL) OFFSET 0 ROWS FETCH NEXT 5 ROWS ONLY`, statement.Prepare().Command())
+}
+
+func TestSQLServerLoadOrderByLimit(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	statement := NewLoadStatement(nil, connection.NewSQLServerInfo()).Table(model.Table).Fields(xpr.Field(model, "Name"))
+	statement.OrderBy(xpr.Asc(xpr.Field(model, "Counter")).NullsFirst())
+	statement.Limit(5).Offset(10)
+
+	require.Equal(t, `SELECT [name] FROM dialectentity ORDER BY CASE WHEN [counter] IS NULL THEN 0 ELSE 1 END,[counter] ASC OFFSET 10 ROWS FETCH NEXT 5 ROWS ONLY`, statement.Prepare().Command())
+}
+
+func TestSQLServerLoadFullJoin(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	statement := NewLoadStatement(nil, connection.NewSQLServerInfo()).Table(model.Table).Alias("e")
+	statement.Fields(xpr.AliasField("e", model, "Name"))
+	statement.Join(JoinTypeFull, "other", xpr.Equals(xpr.AliasField("o", model, "ID"), xpr.AliasField("e", model, "ID")), "o")
+
+	operation := statement.Prepare()
+
+	require.NoError(t, operation.Err())
+	require.Equal(t, `SELECT e.[name] FROM dialectentity AS e FULL OUTER JOIN other AS o ON o.[id] = e.[id]`, operation.Command())
+}
+
+func TestSQLServerLoadWithRecursive(t *testing.T) {
+	info := connection.NewSQLServerInfo()
+
+	anchor := NewLoadStatement(nil, info).Table("category").Fields(xpr.Column("id")).Where(xpr.Equals(xpr.Column("id"), xpr.Parameter()))
+	recursive := NewLoadStatement(nil, info).Table("category").Alias("c").Fields(xpr.AliasColumn("c", "id"))
+	recursive.Join(JoinTypeInner, xpr.Table("tree"), xpr.Equals(xpr.AliasColumn("c", "parent"), xpr.AliasColumn("t", "id")), "t")
+
+	statement := NewLoadStatement(nil, info).WithRecursive("tree", anchor, recursive).Table("tree").Fields(xpr.Column("id"))
+
+	require.Equal(t, `WITH tree AS (SELECT [id] FROM category WHERE [id] = @p1 UNION ALL SELECT c.[id] FROM category AS c INNER JOIN tree AS t ON c.[parent] = t.[id]) SELECT [id] FROM tree`, statement.Prepare().Command())
+}
+
+func TestSQLServerLoadDistinctCount(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	statement := NewLoadStatement(nil, connection.NewSQLServerInfo()).Table(model.Table).Distinct()
+	statement.Fields(xpr.Field(model, "Active"), xpr.CountDistinct(xpr.Field(model, "Name")))
+	statement.GroupBy(xpr.Field(model, "Active"))
+
+	require.Equal(t, `SELECT DISTINCT [active],COUNT(DISTINCT [name]) FROM dialectentity GROUP BY [active]`, statement.Prepare().Command())
+}
+
+func TestSQLServerBatchInsert(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	require.Equal(t, 1000, NewInsertStatement(model, nil, connection.NewSQLServerInfo()).Columns("Name").PrepareBatch().BatchSize())
+	require.Equal(t, 699, NewInsertStatement(model, nil, connection.NewSQLServerInfo()).Columns("Name", "Counter", "Active").PrepareBatch().BatchSize())
+}
+
+func TestSQLServerInsertReturning(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	statement := NewInsertStatement(model, nil, connection.NewSQLServerInfo()).Columns("Name", "Counter").Returning(xpr.Field(model, "ID"))
+
+	require.Equal(t, `INSERT INTO dialectentity ([name],[counter]) OUTPUT INSERTED.[id] VALUES(@p1,@p2)`, statement.PrepareReturning().Command())
+	require.Equal(t, `INSERT INTO dialectentity ([name],[counter]) VALUES(@p1,@p2)`, statement.Prepare().Command())
+	require.Error(t, statement.ReturnID().Prepare().Err())
+}
+
+func TestSQLServerDeleteReturning(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	statement := NewDeleteStatement(model, nil, connection.NewSQLServerInfo()).Where(xpr.Equals(xpr.Field(model, "Active"), xpr.Parameter())).Returning(xpr.Field(model, "ID"), xpr.Field(model, "Name"))
+
+	require.Equal(t, `DELETE FROM dialectentity OUTPUT DELETED.[id],DELETED.[name] WHERE [active] = @p1`, statement.PrepareReturning().Command())
+	require.Equal(t, `DELETE FROM dialectentity WHERE [active] = @p1`, statement.Prepare().Command())
+}
+
+func TestSQLServerSavepoint(t *testing.T) {
+	info := connection.NewSQLServerInfo()
+
+	require.Equal(t, "SAVE TRANSACTION savepoint1", NewSavepoint(nil, info, connection.SavepointCreate, "savepoint1").Prepare().Command())
+	require.Equal(t, "ROLLBACK TRANSACTION savepoint1", NewSavepoint(nil, info, connection.SavepointRollback, "savepoint1").Prepare().Command())
+	require.Equal(t, "", NewSavepoint(nil, info, connection.SavepointRelease, "savepoint1").Prepare().Command())
+}
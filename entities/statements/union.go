@@ -1,6 +0,0 @@
-package statements
-
-type union struct {
-	statement *PreparedLoadStatement
-	all       bool
-}
\ No newline at end of file
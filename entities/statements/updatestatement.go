@@ -1,22 +1,23 @@
 package statements
 
 import (
-	"database/sql"
 	"strings"
 
 	"github.com/verticalgmbh/database-go/connection"
 	"github.com/verticalgmbh/database-go/entities/models"
 	"github.com/verticalgmbh/database-go/entities/walkers"
+	"github.com/verticalgmbh/database-go/interfaces"
 )
 
 // UpdateStatement statement used to update data in a database
 type UpdateStatement struct {
-	connection     *sql.DB
+	connection     interfaces.IExecutor
 	connectioninfo connection.IConnectionInfo
 	model          *models.EntityModel
 
-	updates []interface{}
-	where   interface{}
+	updates   []interface{}
+	where     interface{}
+	returning []interface{} // fields of updated rows to return
 }
 
 // NewUpdateStatement creates a statement used to update entities of a database
@@ -28,7 +29,7 @@ type UpdateStatement struct {
 //
 // **Returns**
 //   - UpdateStatement: statement to use to prepare operation
-func NewUpdateStatement(model *models.EntityModel, connection *sql.DB, connectioninfo connection.IConnectionInfo) *UpdateStatement {
+func NewUpdateStatement(model *models.EntityModel, connection interfaces.IExecutor, connectioninfo connection.IConnectionInfo) *UpdateStatement {
 	return &UpdateStatement{
 		connection:     connection,
 		connectioninfo: connectioninfo,
@@ -59,9 +60,22 @@ func (statement *UpdateStatement) Set(operations ...interface{}) *UpdateStatemen
 	return statement
 }
 
-func (statement *UpdateStatement) buildCommandText() string {
+// Returning specifies fields of updated rows to return. Execute the statement using PrepareReturning to load the returned rows.
+//
+// **Parameters**
+//   - fields: expressions specifying fields to return, all columns of the model if no fields are specified
+//
+// **Returns**
+//   - *UpdateStatement: current statement for fluent behavior
+func (statement *UpdateStatement) Returning(fields ...interface{}) *UpdateStatement {
+	statement.returning = returningFields(statement.model, fields)
+	return statement
+}
+
+func (statement *UpdateStatement) buildCommandText(returning []interface{}) (string, error) {
 	var command strings.Builder
-	sqlwalker := walkers.NewSqlWalker(statement.connectioninfo, &command)
+	parameters := connection.NewParameters()
+	sqlwalker := walkers.NewSqlWalker(statement.connectioninfo, &command, parameters)
 
 	command.WriteString("UPDATE ")
 	command.WriteString(statement.model.Table)
@@ -71,23 +85,57 @@ func (statement *UpdateStatement) buildCommandText() string {
 		if index > 0 {
 			command.WriteRune(',')
 		}
-		sqlwalker.Visit(operation)
+		err := sqlwalker.Visit(operation)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	err := writeReturning(statement.connectioninfo, connection.ReturningInline, false, returning, &command, parameters)
+	if err != nil {
+		return "", err
 	}
 
 	if statement.where != nil {
 		command.WriteString(" WHERE ")
-		sqlwalker.Visit(statement.where)
+		err = sqlwalker.Visit(statement.where)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	err = writeReturning(statement.connectioninfo, connection.ReturningTrailing, false, returning, &command, parameters)
+	if err != nil {
+		return "", err
 	}
 
-	return command.String()
+	return command.String(), nil
 }
 
-// Prepare prepares the statement for execution
+// Prepare prepares the statement for execution. Fields specified using Returning are not returned,
+//         use PrepareReturning to load them.
 //
 // **Returns**
 //   - PreparedStatement: statement used to execute command
 func (statement *UpdateStatement) Prepare() *PreparedStatement {
+	command, err := statement.buildCommandText(nil)
 	return &PreparedStatement{
 		connection: statement.connection,
-		command:    statement.buildCommandText()}
+		command:    command,
+		err:        err}
+}
+
+// PrepareReturning prepares the statement for execution returning fields of the updated rows.
+//                  If no fields were specified using Returning all columns of the model are returned.
+//
+// **Returns**
+//   - *PreparedLoadStatement: statement to execute, rows can get mapped to entities using ExecuteEntity
+func (statement *UpdateStatement) PrepareReturning() *PreparedLoadStatement {
+	command, err := statement.buildCommandText(returningFields(statement.model, statement.returning))
+	return &PreparedLoadStatement{
+		command:        command,
+		err:            err,
+		connection:     statement.connection,
+		connectioninfo: statement.connectioninfo,
+		model:          statement.model}
 }
@@ -0,0 +1,197 @@
+package statements
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/verticalgmbh/database-go/connection"
+	"github.com/verticalgmbh/database-go/entities/models"
+	"github.com/verticalgmbh/database-go/interfaces"
+)
+
+// UpsertStatement - statement used to insert data into a database table updating existing rows on conflict
+type UpsertStatement struct {
+	connection     interfaces.IExecutor
+	connectioninfo connection.IConnectionInfo
+	model          *models.EntityModel
+	fields         []string // fields to insert
+	conflict       []string // fields identifying conflicting rows
+	unique         string   // name of unique index identifying conflicting rows
+	update         []string // fields to update on conflict
+	updateset      bool     // determines whether fields to update were specified
+}
+
+// NewUpsertStatement - creates a new statement used to insert data to a database table updating existing rows on conflict
+//
+// **Parameters**
+//   - model:          model of entity of which to insert rows
+//   - connection:     connection used to send sql statements
+//   - connectioninfo: driver specific database information
+//
+// **Returns**
+//   - *UpsertStatement: statement to use to prepare operation
+func NewUpsertStatement(model *models.EntityModel, connection interfaces.IExecutor, connectioninfo connection.IConnectionInfo) *UpsertStatement {
+	return &UpsertStatement{model: model, connection: connection, connectioninfo: connectioninfo}
+}
+
+// Columns specify columns to fill. If no columns are specified all columns which are not autoincrement columns are filled
+//
+// **Parameters**
+//   - fieldnames: names of fields to insert
+//
+// **Returns**
+//   - *UpsertStatement: this statement for fluent behavior
+func (statement *UpsertStatement) Columns(fieldnames ...string) *UpsertStatement {
+	statement.fields = fieldnames
+	return statement
+}
+
+// OnConflict specifies fields identifying conflicting rows. The fields need to be covered by the primary key or a unique index.
+//            If no conflict target is specified the primary key is used. All fields identifying conflicting rows have to be
+//            inserted, so models with an autoincrement primary key need an explicit conflict target.
+//
+// **Parameters**
+//   - fieldnames: names of fields identifying conflicting rows
+//
+// **Returns**
+//   - *UpsertStatement: this statement for fluent behavior
+func (statement *UpsertStatement) OnConflict(fieldnames ...string) *UpsertStatement {
+	statement.conflict = fieldnames
+	statement.unique = ""
+	return statement
+}
+
+// OnConflictUnique specifies a unique index of the model identifying conflicting rows
+//
+// **Parameters**
+//   - name: name of unique index as specified in the model
+//
+// **Returns**
+//   - *UpsertStatement: this statement for fluent behavior
+func (statement *UpsertStatement) OnConflictUnique(name string) *UpsertStatement {
+	statement.unique = name
+	statement.conflict = nil
+	return statement
+}
+
+// Update specifies fields which are updated with the inserted values on conflict. If Update is not called all inserted
+//        fields which are not part of the conflict target are updated. Calling Update without fields leaves existing rows untouched.
+//
+// Be aware that mysql ignores the conflict target and updates rows conflicting in any unique index.
+//
+// **Parameters**
+//   - fieldnames: names of fields to update
+//
+// **Returns**
+//   - *UpsertStatement: this statement for fluent behavior
+func (statement *UpsertStatement) Update(fieldnames ...string) *UpsertStatement {
+	statement.update = fieldnames
+	statement.updateset = true
+	return statement
+}
+
+func (statement *UpsertStatement) columnNames(fieldnames []string) ([]string, error) {
+	var columns []string
+	for _, field := range fieldnames {
+		column := statement.model.ColumnFromField(field)
+		if column == nil {
+			return nil, fmt.Errorf("Entity field '%s' does not exist", field)
+		}
+
+		columns = append(columns, column.Name())
+	}
+	return columns, nil
+}
+
+func (statement *UpsertStatement) buildUpsert() (*connection.Upsert, error) {
+	upsert := &connection.Upsert{Table: statement.model.Table}
+
+	var err error
+	if len(statement.fields) > 0 {
+		upsert.Columns, err = statement.columnNames(statement.fields)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		for _, column := range statement.model.Columns() {
+			if !column.IsAutoIncrement() {
+				upsert.Columns = append(upsert.Columns, column.Name())
+			}
+		}
+	}
+
+	switch {
+	case len(statement.unique) > 0:
+		for _, unique := range statement.model.Uniques() {
+			if unique.Name() == statement.unique {
+				upsert.Conflict = unique.Columns()
+			}
+		}
+
+		if upsert.Conflict == nil {
+			return nil, fmt.Errorf("Model '%s' has no unique index '%s'", statement.model.Table, statement.unique)
+		}
+	case len(statement.conflict) > 0:
+		upsert.Conflict, err = statement.columnNames(statement.conflict)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		for _, column := range statement.model.PrimaryKeys() {
+			upsert.Conflict = append(upsert.Conflict, column.Name())
+		}
+
+		if upsert.Conflict == nil {
+			return nil, fmt.Errorf("Model '%s' has no primary key to detect conflicts", statement.model.Table)
+		}
+	}
+
+	for _, column := range upsert.Conflict {
+		if !containsColumn(upsert.Columns, column) {
+			return nil, fmt.Errorf("Conflict column '%s' is not inserted into '%s' and is unable to detect conflicting rows", column, statement.model.Table)
+		}
+	}
+
+	if statement.updateset {
+		upsert.Update, err = statement.columnNames(statement.update)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		for _, column := range upsert.Columns {
+			if !containsColumn(upsert.Conflict, column) {
+				upsert.Update = append(upsert.Update, column)
+			}
+		}
+	}
+
+	return upsert, nil
+}
+
+func containsColumn(columns []string, column string) bool {
+	for _, value := range columns {
+		if value == column {
+			return true
+		}
+	}
+	return false
+}
+
+// Prepare prepares the upsert statement for execution. If the statement can not be represented
+//         in the database the error is available using Err and returned when executing the statement.
+//
+// **Returns**
+//   - *PreparedStatement: statement to execute
+func (statement *UpsertStatement) Prepare() *PreparedStatement {
+	var command strings.Builder
+
+	upsert, err := statement.buildUpsert()
+	if err == nil {
+		err = statement.connectioninfo.EvaluateUpsert(upsert, &command)
+	}
+
+	return &PreparedStatement{
+		command:    command.String(),
+		connection: statement.connection,
+		err:        err}
+}
@@ -0,0 +1,113 @@
+package statements
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+	"github.com/verticalgmbh/database-go/connection"
+	"github.com/verticalgmbh/database-go/entities/models"
+)
+
+type UpsertEntity struct {
+	Key     string `database:"primarykey"`
+	Tenant  string `database:"unique=tenantcode"`
+	Code    string `database:"unique=tenantcode"`
+	Counter int
+}
+
+func TestUpsertPrimaryKey(t *testing.T) {
+	database, _ := sql.Open("sqlite3", ":memory:")
+	defer database.Close()
+
+	model := models.CreateModel(reflect.TypeOf(UpsertEntity{}))
+	_, err := NewCreateStatement(model, database, &connection.SqliteInfo{}).Prepare().Execute()
+	require.NoError(t, err)
+
+	operation := NewUpsertStatement(model, database, &connection.SqliteInfo{}).Prepare()
+	require.Equal(t, "INSERT INTO upsertentity ([key],[tenant],[code],[counter]) VALUES(?,?,?,?) ON CONFLICT([key]) DO UPDATE SET [tenant] = excluded.[tenant],[code] = excluded.[code],[counter] = excluded.[counter]", operation.Command())
+
+	_, err = operation.Execute("first", "tenant", "a", 1)
+	require.NoError(t, err)
+	_, err = operation.Execute("first", "tenant", "b", 2)
+	require.NoError(t, err)
+
+	result, err := NewLoadStatement(database, &connection.SqliteInfo{}).Model(model).Prepare().ExecuteEntity()
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{&UpsertEntity{Key: "first", Tenant: "tenant", Code: "b", Counter: 2}}, result)
+}
+
+func TestUpsertUnique(t *testing.T) {
+	database, _ := sql.Open("sqlite3", ":memory:")
+	defer database.Close()
+
+	model := models.CreateModel(reflect.TypeOf(UpsertEntity{}))
+	_, err := NewCreateStatement(model, database, &connection.SqliteInfo{}).Prepare().Execute()
+	require.NoError(t, err)
+
+	operation := NewUpsertStatement(model, database, &connection.SqliteInfo{}).OnConflictUnique("tenantcode").Update("Counter").Prepare()
+	require.NoError(t, operation.Err())
+
+	_, err = operation.Execute("first", "tenant", "a", 1)
+	require.NoError(t, err)
+	_, err = operation.Execute("second", "tenant", "a", 5)
+	require.NoError(t, err)
+
+	result, err := NewLoadStatement(database, &connection.SqliteInfo{}).Model(model).Prepare().ExecuteEntity()
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{&UpsertEntity{Key: "first", Tenant: "tenant", Code: "a", Counter: 5}}, result)
+}
+
+func TestUpsertDoNothing(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(UpsertEntity{}))
+
+	operation := NewUpsertStatement(model, nil, &connection.SqliteInfo{}).Columns("Key", "Counter").Update().Prepare()
+
+	require.Equal(t, "INSERT INTO upsertentity ([key],[counter]) VALUES(?,?) ON CONFLICT([key]) DO NOTHING", operation.Command())
+}
+
+func TestUpsertErrors(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(UpsertEntity{}))
+	withoutkey := models.CreateModel(reflect.TypeOf(LoadModel{}))
+
+	require.Error(t, NewUpsertStatement(model, nil, &connection.SqliteInfo{}).OnConflictUnique("missing").Prepare().Err())
+	require.Error(t, NewUpsertStatement(model, nil, &connection.SqliteInfo{}).Columns("Missing").Prepare().Err())
+	require.Error(t, NewUpsertStatement(withoutkey, nil, &connection.SqliteInfo{}).Prepare().Err())
+	require.NoError(t, NewUpsertStatement(withoutkey, nil, &connection.SqliteInfo{}).OnConflict("Something").Prepare().Err())
+}
+
+func TestUpsertConflictNotInserted(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(UpsertEntity{}))
+	identitymodel := models.CreateModel(reflect.TypeOf(DialectEntity{}))
+
+	require.Error(t, NewUpsertStatement(model, nil, &connection.SqliteInfo{}).Columns("Tenant", "Code", "Counter").Prepare().Err())
+	require.Error(t, NewUpsertStatement(model, nil, &connection.SqliteInfo{}).Columns("Key", "Tenant").OnConflictUnique("tenantcode").Prepare().Err())
+	require.Error(t, NewUpsertStatement(identitymodel, nil, connection.NewSQLServerInfo()).Prepare().Err())
+	require.Error(t, NewUpsertStatement(identitymodel, nil, connection.NewPostgresInfo()).Columns("ID", "Name").OnConflict("Name", "Counter").Prepare().Err())
+	require.NoError(t, NewUpsertStatement(identitymodel, nil, connection.NewSQLServerInfo()).OnConflict("Name").Prepare().Err())
+}
+
+func TestUpsertDialects(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(UpsertEntity{}))
+
+	upsert := func(info connection.IConnectionInfo) string {
+		return NewUpsertStatement(model, nil, info).Columns("Key", "Code", "Counter").Prepare().Command()
+	}
+
+	require.Equal(t, `INSERT INTO upsertentity ("key","code","counter") VALUES($1,$2,$3) ON CONFLICT("key") DO UPDATE SET "code" = excluded."code","counter" = excluded."counter"`, upsert(connection.NewPostgresInfo()))
+	require.Equal(t, "INSERT INTO upsertentity (`key`,`code`,`counter`) VALUES(?,?,?) ON DUPLICATE KEY UPDATE `code` = VALUES(`code`),`counter` = VALUES(`counter`)", upsert(connection.NewMySQLInfo()))
+	require.Equal(t, `MERGE INTO upsertentity WITH (HOLDLOCK) AS target USING (VALUES(@p1,@p2,@p3)) AS source ([key],[code],[counter]) ON target.[key] = source.[key] WHEN MATCHED THEN UPDATE SET [code] = source.[code],[counter] = source.[counter] WHEN NOT MATCHED THEN INSERT ([key],[code],[counter]) VALUES(source.[key],source.[code],source.[counter]);`, upsert(connection.NewSQLServerInfo()))
+}
+
+func TestUpsertDialectsDoNothing(t *testing.T) {
+	model := models.CreateModel(reflect.TypeOf(UpsertEntity{}))
+
+	upsert := func(info connection.IConnectionInfo) string {
+		return NewUpsertStatement(model, nil, info).Columns("Key", "Counter").OnConflict("Key").Update().Prepare().Command()
+	}
+
+	require.Equal(t, "INSERT INTO upsertentity (`key`,`counter`) VALUES(?,?) ON DUPLICATE KEY UPDATE `key` = `key`", upsert(connection.NewMySQLInfo()))
+	require.Equal(t, `MERGE INTO upsertentity WITH (HOLDLOCK) AS target USING (VALUES(@p1,@p2)) AS source ([key],[counter]) ON target.[key] = source.[key] WHEN NOT MATCHED THEN INSERT ([key],[counter]) VALUES(source.[key],source.[counter]);`, upsert(connection.NewSQLServerInfo()))
+}
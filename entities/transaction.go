@@ -0,0 +1,141 @@
+package entities
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/verticalgmbh/database-go/connection"
+	"github.com/verticalgmbh/database-go/entities/statements"
+)
+
+// TransactionOptions options for transactions run using RunInTransaction
+type TransactionOptions struct {
+	Isolation  sql.IsolationLevel // isolation level of the transaction, default level of the driver if zero
+	ReadOnly   bool               // determines whether the transaction is read only
+	Retries    int                // number of times the transaction is retried if it failed due to a concurrent transaction
+	Backoff    time.Duration      // delay before the first retry, doubled for every further retry
+	MaxBackoff time.Duration      // upper limit of the delay between retries, unlimited if zero
+}
+
+// transactor executor which is able to start transactions like *sql.DB and *sql.Conn
+type transactor interface {
+	BeginTx(ctx context.Context, options *sql.TxOptions) (*sql.Tx, error)
+}
+
+// runTransaction runs an operation in a transaction which is committed if the operation succeeds.
+// The transaction is rolled back if the operation returns an error or panics.
+func runTransaction(ctx context.Context, starter transactor, options *sql.TxOptions, operation func(transaction *sql.Tx) error) error {
+	transaction, err := starter.BeginTx(ctx, options)
+	if err != nil {
+		return err
+	}
+
+	// rollback has no effect if the transaction was committed
+	defer transaction.Rollback()
+
+	err = operation(transaction)
+	if err != nil {
+		return err
+	}
+
+	return transaction.Commit()
+}
+
+// RunInTransaction runs an operation in a transaction. The transaction is committed if the operation succeeds
+//                  and rolled back if the operation returns an error or panics. If the transaction fails due to
+//                  a conflict with a concurrent transaction it is retried as specified in the options.
+//
+//                  If the entitymanager is scoped to a transaction already, the operation is run in a savepoint
+//                  instead which is released on success and rolled back on failure. Options are ignored in this case
+//                  since conflicts have to be resolved by retrying the outermost transaction.
+//
+// **Parameters**
+//   - ctx:       context the transaction is bound to
+//   - options:   options of the transaction, nil to run the transaction once using default options
+//   - operation: operation to run, gets passed an entitymanager scoped to the transaction
+//
+// **Returns**
+//   - error: error returned by the operation of the last attempt or error if transaction could not get committed
+func (manager *EntityManager) RunInTransaction(ctx context.Context, options *TransactionOptions, operation func(transaction *EntityManager) error) error {
+	if _, ok := manager.connection.(*sql.Tx); ok {
+		return manager.runInSavepoint(ctx, operation)
+	}
+
+	starter, ok := manager.connection.(transactor)
+	if !ok {
+		return fmt.Errorf("Unable to start a transaction using an executor of type %T", manager.connection)
+	}
+
+	if options == nil {
+		options = &TransactionOptions{}
+	}
+
+	txoptions := &sql.TxOptions{Isolation: options.Isolation, ReadOnly: options.ReadOnly}
+	backoff := options.Backoff
+	for attempt := 0; ; attempt++ {
+		err := runTransaction(ctx, starter, txoptions, func(transaction *sql.Tx) error {
+			return operation(manager.Scope(transaction))
+		})
+
+		if err == nil || attempt >= options.Retries || !manager.connectioninfo.IsRetryable(err) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if options.MaxBackoff > 0 && backoff > options.MaxBackoff {
+			backoff = options.MaxBackoff
+		}
+	}
+}
+
+// runInSavepoint runs an operation in a savepoint of the transaction the entitymanager is scoped to
+func (manager *EntityManager) runInSavepoint(ctx context.Context, operation func(transaction *EntityManager) error) error {
+	scoped := manager.Scope(manager.connection)
+	scoped.savepoints = manager.savepoints + 1
+	name := fmt.Sprintf("savepoint%d", scoped.savepoints)
+
+	err := manager.executeSavepoint(ctx, connection.SavepointCreate, name)
+	if err != nil {
+		return fmt.Errorf("Unable to create savepoint: %w", err)
+	}
+
+	completed := false
+	defer func() {
+		// roll back changes of savepoint if operation panics. an error rolling back can not get reported
+		// since the panic is propagated
+		if !completed {
+			manager.executeSavepoint(ctx, connection.SavepointRollback, name)
+		}
+	}()
+
+	err = operation(scoped)
+	completed = true
+	if err != nil {
+		rollbackerr := manager.executeSavepoint(ctx, connection.SavepointRollback, name)
+		if rollbackerr != nil {
+			return fmt.Errorf("%w (unable to roll back savepoint: %s)", err, rollbackerr.Error())
+		}
+		return err
+	}
+
+	return manager.executeSavepoint(ctx, connection.SavepointRelease, name)
+}
+
+// executeSavepoint executes an operation on a savepoint of the transaction the entitymanager is scoped to
+func (manager *EntityManager) executeSavepoint(ctx context.Context, operation connection.SavepointOperation, name string) error {
+	statement := statements.NewSavepoint(manager.connection, manager.connectioninfo, operation, name).Prepare()
+	if statement.Err() == nil && len(statement.Command()) == 0 {
+		return nil
+	}
+
+	_, err := statement.ExecuteContext(ctx)
+	return err
+}
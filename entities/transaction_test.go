@@ -0,0 +1,212 @@
+package entities
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/verticalgmbh/database-go/connection"
+	"github.com/verticalgmbh/database-go/entities/models"
+	"github.com/verticalgmbh/database-go/xpr"
+)
+
+func createTransactionTestManager(t *testing.T) (*EntityManager, *models.EntityModel) {
+	database, err := sql.Open("sqlite3", ":memory:")
+	assert.NoError(t, err)
+	database.SetMaxOpenConns(1)
+
+	entitymanager := NewEntitymanager(database, connection.NewSqliteInfo())
+
+	model := models.CreateModel(reflect.TypeOf(IdentityEntity{}))
+	assert.NoError(t, entitymanager.Create(model))
+	return entitymanager, model
+}
+
+func countEntities(t *testing.T, entitymanager *EntityManager, model *models.EntityModel) int64 {
+	count, err := entitymanager.Load(model, xpr.Count()).Prepare().ExecuteScalar()
+	assert.NoError(t, err)
+	return count.(int64)
+}
+
+func TestRunInTransactionCommit(t *testing.T) {
+	entitymanager, model := createTransactionTestManager(t)
+	defer entitymanager.Close()
+
+	err := entitymanager.RunInTransaction(context.Background(), nil, func(transaction *EntityManager) error {
+		return transaction.InsertEntity(model, &IdentityEntity{Name: "committed"})
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), countEntities(t, entitymanager, model))
+}
+
+func TestRunInTransactionRollbackOnError(t *testing.T) {
+	entitymanager, model := createTransactionTestManager(t)
+	defer entitymanager.Close()
+
+	failure := errors.New("operation failed")
+	err := entitymanager.RunInTransaction(context.Background(), &TransactionOptions{Retries: 3}, func(transaction *EntityManager) error {
+		assert.NoError(t, transaction.InsertEntity(model, &IdentityEntity{Name: "rolledback"}))
+		return failure
+	})
+
+	assert.Equal(t, failure, err)
+	assert.Equal(t, int64(0), countEntities(t, entitymanager, model))
+}
+
+func TestRunInTransactionRollbackOnPanic(t *testing.T) {
+	entitymanager, model := createTransactionTestManager(t)
+	defer entitymanager.Close()
+
+	assert.Panics(t, func() {
+		entitymanager.RunInTransaction(context.Background(), nil, func(transaction *EntityManager) error {
+			assert.NoError(t, transaction.InsertEntity(model, &IdentityEntity{Name: "rolledback"}))
+			panic("operation failed")
+		})
+	})
+
+	assert.Equal(t, int64(0), countEntities(t, entitymanager, model))
+}
+
+func TestRunInTransactionRetry(t *testing.T) {
+	entitymanager, model := createTransactionTestManager(t)
+	defer entitymanager.Close()
+
+	attempts := 0
+	err := entitymanager.RunInTransaction(context.Background(), &TransactionOptions{Retries: 2, Backoff: time.Millisecond}, func(transaction *EntityManager) error {
+		attempts++
+		assert.NoError(t, transaction.InsertEntity(model, &IdentityEntity{Name: "entity"}))
+		if attempts < 3 {
+			return fmt.Errorf("Error executing statement: %w", sqlite3.Error{Code: sqlite3.ErrBusy})
+		}
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+	assert.Equal(t, int64(1), countEntities(t, entitymanager, model))
+
+	attempts = 0
+	err = entitymanager.RunInTransaction(context.Background(), &TransactionOptions{Retries: 1}, func(transaction *EntityManager) error {
+		attempts++
+		return fmt.Errorf("Error executing statement: %w", sqlite3.Error{Code: sqlite3.ErrBusy})
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, 2, attempts)
+}
+
+func TestRunInTransactionSavepoints(t *testing.T) {
+	entitymanager, model := createTransactionTestManager(t)
+	defer entitymanager.Close()
+
+	err := entitymanager.RunInTransaction(context.Background(), nil, func(transaction *EntityManager) error {
+		assert.NoError(t, transaction.InsertEntity(model, &IdentityEntity{Name: "outer"}))
+
+		err := transaction.RunInTransaction(context.Background(), nil, func(savepoint *EntityManager) error {
+			assert.NoError(t, savepoint.InsertEntity(model, &IdentityEntity{Name: "released"}))
+
+			return savepoint.RunInTransaction(context.Background(), nil, func(nested *EntityManager) error {
+				return nested.InsertEntity(model, &IdentityEntity{Name: "nested"})
+			})
+		})
+		assert.NoError(t, err)
+
+		err = transaction.RunInTransaction(context.Background(), nil, func(savepoint *EntityManager) error {
+			assert.NoError(t, savepoint.InsertEntity(model, &IdentityEntity{Name: "rolledback"}))
+			return errors.New("operation failed")
+		})
+		assert.Error(t, err)
+
+		assert.Panics(t, func() {
+			transaction.RunInTransaction(context.Background(), nil, func(savepoint *EntityManager) error {
+				assert.NoError(t, savepoint.InsertEntity(model, &IdentityEntity{Name: "panicked"}))
+				panic("operation failed")
+			})
+		})
+
+		return nil
+	})
+	assert.NoError(t, err)
+
+	names, err := entitymanager.Load(model, xpr.Field(model, "Name")).Prepare().ExecuteSet()
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"outer", "released", "nested"}, names)
+}
+
+func TestRunInTransactionSavepointRollbackError(t *testing.T) {
+	entitymanager, model := createTransactionTestManager(t)
+	defer entitymanager.Close()
+
+	operationerr := errors.New("operation failed")
+	err := entitymanager.RunInTransaction(context.Background(), nil, func(transaction *EntityManager) error {
+		return transaction.RunInTransaction(context.Background(), nil, func(savepoint *EntityManager) error {
+			assert.NoError(t, savepoint.InsertEntity(model, &IdentityEntity{Name: "released"}))
+
+			// releasing the savepoint prematurely makes rolling it back fail
+			_, err := savepoint.connection.(*sql.Tx).Exec("RELEASE savepoint1")
+			assert.NoError(t, err)
+			return operationerr
+		})
+	})
+
+	assert.True(t, errors.Is(err, operationerr))
+	assert.Contains(t, err.Error(), "unable to roll back savepoint")
+	assert.Equal(t, int64(0), countEntities(t, entitymanager, model))
+}
+
+func TestUpdateSchemaInTransaction(t *testing.T) {
+	database, err := sql.Open("sqlite3", ":memory:")
+	assert.NoError(t, err)
+	database.SetMaxOpenConns(1)
+
+	entitymanager := NewEntitymanager(database, connection.NewSqliteInfo())
+	defer entitymanager.Close()
+
+	model := models.CreateModel(reflect.TypeOf(CreateEntity{}))
+	operationerr := errors.New("operation failed")
+
+	// schema operations have to use the transaction since the only connection is held by it
+	err = entitymanager.RunInTransaction(context.Background(), nil, func(transaction *EntityManager) error {
+		assert.NoError(t, transaction.UpdateSchema(model))
+
+		exists, err := transaction.Exists(model)
+		assert.NoError(t, err)
+		assert.True(t, exists)
+		return operationerr
+	})
+	assert.Equal(t, operationerr, err)
+
+	exists, err := entitymanager.Exists(model)
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	_, err = database.Exec("CREATE TABLE createentity (id INTEGER PRIMARY KEY AUTOINCREMENT, guid TEXT UNIQUE, firstname TEXT, lastname TEXT)")
+	assert.NoError(t, err)
+
+	err = entitymanager.RunInTransaction(context.Background(), nil, func(transaction *EntityManager) error {
+		assert.NoError(t, transaction.UpdateSchema(model))
+		return operationerr
+	})
+	assert.Equal(t, operationerr, err)
+
+	schema, err := entitymanager.connectioninfo.GetSchema(database, model.Table)
+	assert.NoError(t, err)
+	assert.Len(t, schema.(*models.Table).Columns(), 4)
+
+	err = entitymanager.RunInTransaction(context.Background(), nil, func(transaction *EntityManager) error {
+		return transaction.UpdateSchema(model)
+	})
+	assert.NoError(t, err)
+
+	schema, err = entitymanager.connectioninfo.GetSchema(database, model.Table)
+	assert.NoError(t, err)
+	assert.Len(t, schema.(*models.Table).Columns(), 6)
+}
@@ -12,10 +12,25 @@ import (
 	"github.com/verticalgmbh/database-go/xpr"
 )
 
+// ISubquery statement which is able to write its command as part of another command
+type ISubquery interface {
+
+	// WriteCommand writes the command of the statement as part of another command
+	//
+	// **Parameters**
+	//   - command:    command to write the statement to
+	//   - parameters: parameters already written to the command
+	//
+	// **Returns**
+	//   - error: error if the statement can not be written as part of another command
+	WriteCommand(command *strings.Builder, parameters *connection.Parameters) error
+}
+
 // SqlWalker used to convert expressions to sql strings
 type SqlWalker struct {
 	connectioninfo connection.IConnectionInfo
 	builder        *strings.Builder
+	parameters     *connection.Parameters // parameters already written to the command
 }
 
 // NewSqlWalker creates a new SqlWalker
@@ -23,13 +38,15 @@ type SqlWalker struct {
 // **Parameters**
 //   - connectioninfo: driver specific connection info
 //   - builder       : command builder to fill
+//   - parameters    : parameters already written to the command, shared by all walkers writing to the same command
 //
 // **Returns**
 //   - *SqlWalker: created sql walker
-func NewSqlWalker(connectioninfo connection.IConnectionInfo, builder *strings.Builder) *SqlWalker {
+func NewSqlWalker(connectioninfo connection.IConnectionInfo, builder *strings.Builder, parameters *connection.Parameters) *SqlWalker {
 	return &SqlWalker{
 		connectioninfo: connectioninfo,
-		builder:        builder}
+		builder:        builder,
+		parameters:     parameters}
 }
 
 // Visit creates an sql representation of a given expression tree
@@ -38,53 +55,60 @@ func NewSqlWalker(connectioninfo connection.IConnectionInfo, builder *strings.Bu
 //   - tree: expression to evaluate
 func (walker *SqlWalker) Visit(tree interface{}) error {
 	if pstat, ok := tree.(interfaces.IPreparedOperation); ok {
-		walker.visitStatement(pstat)
-		return nil
+		return walker.visitStatement(pstat)
 	}
 
 	switch v := tree.(type) {
 	default:
 		walker.visitValue(tree)
 	case *xpr.UnaryNode:
-		walker.visitUnary(v)
+		return walker.visitUnary(v)
 	case xpr.UnaryNode:
-		walker.visitUnary(&v)
+		return walker.visitUnary(&v)
 	case *xpr.BinaryNode:
-		walker.visitBinary(v)
+		return walker.visitBinary(v)
 	case xpr.BinaryNode:
-		walker.visitBinary(&v)
+		return walker.visitBinary(&v)
 	case xpr.ParameterNode:
-		walker.visitParameter(&v)
+		return walker.visitParameter(&v)
 	case *xpr.ParameterNode:
-		walker.visitParameter(v)
+		return walker.visitParameter(v)
 	case xpr.FieldNode:
-		walker.visitField(&v)
+		return walker.visitField(&v)
 	case *xpr.FieldNode:
-		walker.visitField(v)
+		return walker.visitField(v)
 	case xpr.ColumnNode:
 		walker.visitColumn(&v)
 	case *xpr.ColumnNode:
 		walker.visitColumn(v)
 	case xpr.AliasNode:
-		walker.visitAlias(&v)
+		return walker.visitAlias(&v)
 	case *xpr.AliasNode:
-		walker.visitAlias(v)
+		return walker.visitAlias(v)
 	case xpr.FunctionNode:
 		return walker.visitFunction(&v)
 	case *xpr.FunctionNode:
 		return walker.visitFunction(v)
+	case xpr.AsNode:
+		return walker.visitAs(&v)
+	case *xpr.AsNode:
+		return walker.visitAs(v)
+	case xpr.OrderNode:
+		return walker.visitOrder(&v)
+	case *xpr.OrderNode:
+		return walker.visitOrder(v)
 	case *models.ColumnDescriptor:
 		walker.builder.WriteString(v.Name())
 	case models.ColumnDescriptor:
 		walker.builder.WriteString(v.Name())
 	case *xpr.InCollectionNode:
-		walker.visitIn(v)
+		return walker.visitIn(v)
 	case xpr.InCollectionNode:
-		walker.visitIn(&v)
+		return walker.visitIn(&v)
 	case *xpr.StatementNode:
-		walker.visitStatement(v.Statement)
+		return walker.visitStatement(v.Statement)
 	case xpr.StatementNode:
-		walker.visitStatement(v.Statement)
+		return walker.visitStatement(v.Statement)
 	case *xpr.TableNode:
 		walker.builder.WriteString(v.Name)
 	case xpr.TableNode:
@@ -94,48 +118,94 @@ func (walker *SqlWalker) Visit(tree interface{}) error {
 	return nil
 }
 
-func (walker *SqlWalker) visitStatement(statement interfaces.IPreparedOperation) {
+func (walker *SqlWalker) visitStatement(statement interfaces.IPreparedOperation) error {
 	walker.builder.WriteRune('(')
-	walker.builder.WriteString(statement.Command())
+	if subquery, ok := statement.(ISubquery); ok {
+		// subqueries continue the parameter numbering of the command they are part of
+		err := subquery.WriteCommand(walker.builder, walker.getParameters())
+		if err != nil {
+			return err
+		}
+	} else {
+		walker.builder.WriteString(statement.Command())
+	}
 	walker.builder.WriteRune(')')
+	return nil
 }
 
-func (walker *SqlWalker) visitIn(node *xpr.InCollectionNode) {
-	walker.Visit(node.Item())
+func (walker *SqlWalker) visitIn(node *xpr.InCollectionNode) error {
+	err := walker.Visit(node.Item())
+	if err != nil {
+		return err
+	}
+
 	walker.builder.WriteString(" IN (")
 	for index, item := range node.Collection() {
 		if index > 0 {
 			walker.builder.WriteRune(',')
 		}
-		walker.Visit(item)
+
+		err = walker.Visit(item)
+		if err != nil {
+			return err
+		}
 	}
 	walker.builder.WriteRune(')')
+	return nil
 }
 
 func (walker *SqlWalker) visitFunction(node *xpr.FunctionNode) error {
 	return walker.connectioninfo.EvaluateFunction(node, walker.builder, walker.Visit)
 }
 
-func (walker *SqlWalker) visitParameter(node *xpr.ParameterNode) {
-	walker.connectioninfo.EvaluateParameter(node, walker.builder)
+func (walker *SqlWalker) visitAs(node *xpr.AsNode) error {
+	err := walker.Visit(node.Expression)
+	if err != nil {
+		return err
+	}
+
+	walker.builder.WriteString(" AS ")
+	walker.builder.WriteString(walker.connectioninfo.MaskColumn(node.Name))
+	return nil
+}
+
+func (walker *SqlWalker) visitOrder(node *xpr.OrderNode) error {
+	return walker.connectioninfo.EvaluateOrder(node, walker.builder, walker.Visit)
+}
+
+// getParameters provides the parameters already written to the command
+func (walker *SqlWalker) getParameters() *connection.Parameters {
+	if walker.parameters == nil {
+		walker.parameters = connection.NewParameters()
+	}
+	return walker.parameters
 }
 
-func (walker *SqlWalker) visitAlias(node *xpr.AliasNode) {
+func (walker *SqlWalker) visitParameter(node *xpr.ParameterNode) error {
+	return walker.connectioninfo.EvaluateParameter(node, walker.getParameters(), walker.builder)
+}
+
+func (walker *SqlWalker) visitAlias(node *xpr.AliasNode) error {
 	walker.builder.WriteString(node.Alias)
 	walker.builder.WriteRune('.')
-	walker.Visit(node.Field)
+	return walker.Visit(node.Field)
 }
 
-func (walker *SqlWalker) visitField(node *xpr.FieldNode) {
+func (walker *SqlWalker) visitField(node *xpr.FieldNode) error {
 	column := node.Model().ColumnFromField(node.Name())
+	if column == nil {
+		return fmt.Errorf("Field '%s' is not mapped to a column of '%s'", node.Name(), node.Model().Table)
+	}
+
 	walker.builder.WriteString(walker.connectioninfo.MaskColumn(column.Name()))
+	return nil
 }
 
 func (walker *SqlWalker) visitColumn(node *xpr.ColumnNode) {
 	walker.builder.WriteString(walker.connectioninfo.MaskColumn(node.Name))
 }
 
-func (walker *SqlWalker) visitUnary(node *xpr.UnaryNode) {
+func (walker *SqlWalker) visitUnary(node *xpr.UnaryNode) error {
 	switch node.Operator() {
 	case xpr.Not:
 		walker.builder.WriteRune('!')
@@ -145,11 +215,14 @@ func (walker *SqlWalker) visitUnary(node *xpr.UnaryNode) {
 		walker.builder.WriteRune('-')
 	}
 
-	walker.Visit(node.Value())
+	return walker.Visit(node.Value())
 }
 
-func (walker *SqlWalker) visitBinary(node *xpr.BinaryNode) {
-	walker.Visit(node.Lhs())
+func (walker *SqlWalker) visitBinary(node *xpr.BinaryNode) error {
+	err := walker.Visit(node.Lhs())
+	if err != nil {
+		return err
+	}
 
 	switch node.Operator() {
 	case xpr.BinaryAnd:
@@ -159,14 +232,14 @@ func (walker *SqlWalker) visitBinary(node *xpr.BinaryNode) {
 	case xpr.BinaryEquals:
 		if node.Rhs() == nil {
 			walker.builder.WriteString(" IS NULL")
-			return
+			return nil
 		}
 
 		walker.builder.WriteString(" = ")
 	case xpr.BinaryNotEqual:
 		if node.Rhs() == nil {
 			walker.builder.WriteString(" IS NOT NULL")
-			return
+			return nil
 		}
 
 		walker.builder.WriteString(" <> ")
@@ -202,7 +275,7 @@ func (walker *SqlWalker) visitBinary(node *xpr.BinaryNode) {
 		walker.builder.WriteString(" ^ ")
 	}
 
-	walker.Visit(node.Rhs())
+	return walker.Visit(node.Rhs())
 }
 
 func (walker *SqlWalker) visitValue(value interface{}) {
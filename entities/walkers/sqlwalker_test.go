@@ -32,3 +32,38 @@ func TestInExpression(t *testing.T) {
 
 	assert.Equal(t, `? IN (1,6,33,4)`, command.String())
 }
+
+func TestOrderExpression(t *testing.T) {
+	var command strings.Builder
+
+	walker := SqlWalker{
+		connectioninfo: &connection.SqliteInfo{},
+		builder:        &command}
+
+	walker.Visit(xpr.Desc(xpr.Column("name")))
+
+	assert.Equal(t, `[name] DESC`, command.String())
+}
+
+func TestAsExpression(t *testing.T) {
+	var command strings.Builder
+
+	walker := SqlWalker{
+		connectioninfo: &connection.SqliteInfo{},
+		builder:        &command}
+
+	walker.Visit(xpr.As(xpr.Max(xpr.Column("counter")), "maximum"))
+
+	assert.Equal(t, `MAX([counter]) AS [maximum]`, command.String())
+}
+
+func TestNestedExpressionError(t *testing.T) {
+	var command strings.Builder
+
+	walker := SqlWalker{
+		connectioninfo: &connection.SqliteInfo{},
+		builder:        &command}
+
+	assert.Error(t, walker.Visit(xpr.And(xpr.Equals(xpr.Column("name"), xpr.Parameter()), xpr.In(xpr.Column("counter"), 1, xpr.Coalesce()))))
+	assert.Error(t, walker.Visit(&xpr.AliasNode{Alias: "e", Field: xpr.Coalesce()}))
+}
@@ -1,10 +1,16 @@
 module github.com/verticalgmbh/database-go
 
-go 1.13
+go 1.18
 
 require (
 	github.com/go-errors/errors v1.0.1
-	github.com/mattn/go-sqlite3 v2.0.2+incompatible
+	github.com/mattn/go-sqlite3 v1.14.33
 	github.com/stretchr/testify v1.4.0
 	github.com/verticalgmbh/collections-go v0.1.1
 )
+
+require (
+	github.com/davecgh/go-spew v1.1.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	gopkg.in/yaml.v2 v2.2.2 // indirect
+)
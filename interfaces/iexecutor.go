@@ -0,0 +1,56 @@
+package interfaces
+
+import (
+	"context"
+	"database/sql"
+)
+
+// IExecutor executes commands against a database. Implemented by *sql.DB, *sql.Tx and *sql.Conn
+type IExecutor interface {
+
+	// QueryContext executes a command returning rows
+	//
+	// **Parameters**
+	//   - ctx:       context used to cancel execution
+	//   - query:     sql-command to execute
+	//   - arguments: parameter values for command
+	//
+	// **Returns**
+	//   - *sql.Rows: result rows
+	//   - error:     error if command could not get executed
+	QueryContext(ctx context.Context, query string, arguments ...interface{}) (*sql.Rows, error)
+
+	// QueryRowContext executes a command returning at most one row
+	//
+	// **Parameters**
+	//   - ctx:       context used to cancel execution
+	//   - query:     sql-command to execute
+	//   - arguments: parameter values for command
+	//
+	// **Returns**
+	//   - *sql.Row: result row, errors are deferred until the row is scanned
+	QueryRowContext(ctx context.Context, query string, arguments ...interface{}) *sql.Row
+
+	// ExecContext executes a command without returning rows
+	//
+	// **Parameters**
+	//   - ctx:       context used to cancel execution
+	//   - query:     sql-command to execute
+	//   - arguments: parameter values for command
+	//
+	// **Returns**
+	//   - sql.Result: result containing number of affected rows
+	//   - error:      error if command could not get executed
+	ExecContext(ctx context.Context, query string, arguments ...interface{}) (sql.Result, error)
+
+	// PrepareContext prepares a command for repeated execution
+	//
+	// **Parameters**
+	//   - ctx:   context used to cancel preparation
+	//   - query: sql-command to prepare
+	//
+	// **Returns**
+	//   - *sql.Stmt: prepared statement
+	//   - error:     error if command could not get prepared
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+}
@@ -0,0 +1,7 @@
+package xpr
+
+// AsNode node used to specify a name for an expression in a result set
+type AsNode struct {
+	Expression interface{}
+	Name       string
+}
@@ -5,8 +5,6 @@ import (
 	"github.com/verticalgmbh/database-go/interfaces"
 )
 
-var count *FunctionNode = &FunctionNode{function: FunctionCount}
-
 // And creates a binary node for an AND operation
 //
 // **Parameters**
@@ -220,6 +218,20 @@ func AliasColumn(alias string, columnname string) *AliasNode {
 			Name: columnname}}
 }
 
+// As names an expression in a result set
+//
+// **Parameters**
+//   - expression: expression to name
+//   - name:       name of result column
+//
+// **Returns**
+//   - *AsNode: node to use in field lists
+func As(expression interface{}, name string) *AsNode {
+	return &AsNode{
+		Expression: expression,
+		Name:       name}
+}
+
 // Column - creates a new node representing a column
 func Column(name string) *ColumnNode {
 	return &ColumnNode{
@@ -246,7 +258,33 @@ func NamedParameter(name string) *ParameterNode {
 
 // Count used to count number of rows returned
 func Count() *FunctionNode {
-	return count
+	return &FunctionNode{function: FunctionCount}
+}
+
+// CountOf counts values of an expression which are not null
+//
+// **Parameters**
+//   - field: expression which specifies values to count
+//
+// **Returns**
+//   - *FunctionNode: node to use in expression
+func CountOf(field interface{}) *FunctionNode {
+	return &FunctionNode{
+		function:   FunctionCount,
+		parameters: []interface{}{field}}
+}
+
+// CountDistinct counts distinct values of an expression which are not null
+//
+// **Parameters**
+//   - field: expression which specifies values to count
+//
+// **Returns**
+//   - *FunctionNode: node to use in expression
+func CountDistinct(field interface{}) *FunctionNode {
+	return &FunctionNode{
+		function:   FunctionCountDistinct,
+		parameters: []interface{}{field}}
 }
 
 // In checks for existence of an item in a collection
@@ -264,6 +302,31 @@ func Average(field interface{}) *FunctionNode {
 			field}}
 }
 
+// Asc orders a result set ascending by an expression
+//
+// **Parameters**
+//   - field: expression to order by
+//
+// **Returns**
+//   - *OrderNode: node to use in order criterias
+func Asc(field interface{}) *OrderNode {
+	return &OrderNode{
+		field: field}
+}
+
+// Desc orders a result set descending by an expression
+//
+// **Parameters**
+//   - field: expression to order by
+//
+// **Returns**
+//   - *OrderNode: node to use in order criterias
+func Desc(field interface{}) *OrderNode {
+	return &OrderNode{
+		field:      field,
+		descending: true}
+}
+
 // Statement includes a sub statement in an expression
 func Statement(statement interfaces.IPreparedOperation) *StatementNode {
 	return &StatementNode{
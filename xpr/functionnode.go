@@ -4,7 +4,7 @@ package xpr
 type FunctionType int
 
 const (
-	// FunctionCount counts rows in a result set
+	// FunctionCount counts rows in a result set or values of an expression which are not null
 	FunctionCount FunctionType = iota
 
 	// FunctionRandom get a random number
@@ -24,6 +24,9 @@ const (
 
 	// FunctionCoalesce returns first value from list which is not null, null if all values are null
 	FunctionCoalesce
+
+	// FunctionCountDistinct counts distinct values in a result set
+	FunctionCountDistinct
 )
 
 // FunctionNode node in an expression tree representing a database function
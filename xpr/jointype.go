@@ -0,0 +1,21 @@
+package xpr
+
+// JoinType type of join to apply when combining result sets
+type JoinType int8
+
+const (
+	// JoinInner only rows matching in both sources are returned
+	JoinInner JoinType = iota
+
+	// JoinLeft all rows of the left source are returned, combined with matching rows of the right source
+	JoinLeft
+
+	// JoinRight all rows of the right source are returned, combined with matching rows of the left source
+	JoinRight
+
+	// JoinFull all rows of both sources are returned, combined where they match
+	JoinFull
+
+	// JoinCross every row of the left source is combined with every row of the right source
+	JoinCross
+)
@@ -0,0 +1,55 @@
+package xpr
+
+// NullsOrder position of null values in an ordered result set
+type NullsOrder int
+
+const (
+	// NullsDefault null values are ordered as the database does by default
+	NullsDefault NullsOrder = iota
+
+	// NullsFirst null values are ordered before all other values
+	NullsFirst
+
+	// NullsLast null values are ordered after all other values
+	NullsLast
+)
+
+// OrderNode node specifying ordering of a result set by an expression
+type OrderNode struct {
+	field      interface{}
+	descending bool
+	nulls      NullsOrder
+}
+
+// Field expression to order by
+func (node *OrderNode) Field() interface{} {
+	return node.field
+}
+
+// Descending determines whether values are ordered in descending order
+func (node *OrderNode) Descending() bool {
+	return node.descending
+}
+
+// Nulls position of null values in ordered result
+func (node *OrderNode) Nulls() NullsOrder {
+	return node.nulls
+}
+
+// NullsFirst orders null values before all other values
+//
+// **Returns**
+//   - *OrderNode: this node for fluent behavior
+func (node *OrderNode) NullsFirst() *OrderNode {
+	node.nulls = NullsFirst
+	return node
+}
+
+// NullsLast orders null values after all other values
+//
+// **Returns**
+//   - *OrderNode: this node for fluent behavior
+func (node *OrderNode) NullsLast() *OrderNode {
+	node.nulls = NullsLast
+	return node
+}